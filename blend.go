@@ -1,10 +1,14 @@
 package blend
 
 import (
+	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
 
 	"github.com/mewspring/blend/block"
 	"github.com/mewspring/blend/file"
@@ -16,10 +20,83 @@ type Blend struct {
 	Hdr     Header
 	Blocks  []*block.Block
 	OldAddr map[uint64]*block.Block
+	// Diag collects the diagnostics of decoding the blend file and parsing its
+	// block bodies; or nil if not decoded from a file.
+	Diag *block.Diagnostics
+
+	// nextAddr is the lower bound of memory addresses allocated by NewAddr.
+	nextAddr uint64
+	// r is the block reader used to decode the blend file; or nil if not
+	// decoded from a file.
+	r *block.Reader
 }
 
+// Decode decodes the blend file read by d, and returns its file blocks with
+// unparsed bodies; see Block.ParseBody.
+//
+// Decoding uses the resource limits of block.DefaultLimits, which bound the
+// size of each block body to 1 GiB, the DNA to 65536 entries of each kind, and
+// the total size of parsed block bodies to 4 GiB. Use DecodeWithOptions to
+// specify other limits.
 func Decode(d *file.Reader) (*Blend, error) {
-	b := &Blend{OldAddr: make(map[uint64]*block.Block)}
+	return DecodeContext(context.Background(), d, DecodeOptions{})
+}
+
+// DecodeOptions specifies optional behaviour of DecodeWithOptions.
+type DecodeOptions struct {
+	// Strict turns trailing bytes of parsed block bodies and duplicate memory
+	// addresses into errors.
+	Strict bool
+	// Logger, if non-nil, receives the diagnostics of decoding as warnings.
+	// Diagnostics are otherwise only recorded in Blend.Diag.
+	Logger *slog.Logger
+	// Limits bounds the resources used to decode the blend file and parse its
+	// block bodies; see block.Limits. Zero fields use the limits of
+	// block.DefaultLimits, as described for Decode.
+	Limits block.Limits
+	// Progress, if non-nil, is called after each block read.
+	Progress func(Progress)
+}
+
+// Progress reports the progress of decoding a blend file or parsing its block
+// bodies.
+type Progress struct {
+	// Blocks is the number of blocks processed so far, out of TotalBlocks; or
+	// zero if unknown.
+	Blocks, TotalBlocks int
+	// Bytes is the number of bytes processed so far, out of TotalBytes; or zero
+	// if unknown.
+	Bytes, TotalBytes int64
+}
+
+// DecodeWithOptions decodes the blend file read by d as Decode does, with the
+// given options applied. The options also apply to block bodies parsed after
+// decoding.
+func DecodeWithOptions(d *file.Reader, opts DecodeOptions) (*Blend, error) {
+	return DecodeContext(context.Background(), d, opts)
+}
+
+// DecodeContext decodes the blend file read by d as DecodeWithOptions does. The
+// context is checked between blocks, and decoding stops with the error of the
+// context once it is done.
+func DecodeContext(ctx context.Context, d *file.Reader, opts DecodeOptions) (*Blend, error) {
+	var progress Progress
+	if opts.Progress != nil {
+		// Determine file size.
+		size, err := d.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := d.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		progress.TotalBytes = size
+	}
+
+	b := &Blend{
+		OldAddr: make(map[uint64]*block.Block),
+		Diag:    &block.Diagnostics{Strict: opts.Strict, Logger: opts.Logger},
+	}
 
 	// Parse file header.
 	var err error
@@ -28,13 +105,25 @@ func Decode(d *file.Reader) (*Blend, error) {
 		return nil, fmt.Errorf("reading header: %v", err)
 	}
 
-	blkReader := block.NewReader(b.Hdr.Order, b.Hdr.PtrSize, b.Hdr.Ver)
+	blkReader := block.NewReaderWithDiagnostics(b.Hdr.Order, b.Hdr.PtrSize, b.Hdr.Ver, b.Diag)
+	blkReader.Limits = opts.Limits
+	b.r = blkReader
 	// Parse file blocks.
 	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		blk, err := blkReader.ReadBlock(d)
 		if err != nil {
 			return nil, fmt.Errorf("reading block: %v", err)
 		}
+		if opts.Progress != nil {
+			progress.Blocks++
+			if progress.Bytes, err = d.Seek(0, io.SeekCurrent); err != nil {
+				return nil, err
+			}
+			opts.Progress(progress)
+		}
 
 		if blk.Hdr.Code == block.CodeENDB {
 			break
@@ -73,6 +162,102 @@ func Encode(dst io.Writer, b *Blend) error {
 	})
 }
 
+// EncodeOptions specifies optional behaviour of EncodeWithOptions.
+type EncodeOptions struct {
+	// GC removes the blocks of b which are unreachable from its ID blocks
+	// before encoding; see Blend.GC.
+	GC bool
+	// GCStats, if non-nil, receives statistics of the blocks removed by GC.
+	GCStats *[]GCStat
+	// Deterministic renumbers memory addresses and zeroes runtime-only fields,
+	// so that identical content is encoded identically; see
+	// Blend.Deterministic.
+	Deterministic bool
+	// Order and PtrSize, if set, specify the byte order and pointer size of the
+	// output, which are converted from those of b; see Blend.Convert.
+	Order   binary.ByteOrder
+	PtrSize int
+	// Unswapped, if non-nil, receives the raw blocks of unknown layout which
+	// were not converted for the output byte order.
+	Unswapped *[]*block.Block
+}
+
+// EncodeWithOptions writes b to dst as Encode does, with the given options
+// applied. Note that the options may modify b.
+func EncodeWithOptions(dst io.Writer, b *Blend, opts EncodeOptions) error {
+	order, ptrSize := b.Hdr.Order, b.Hdr.PtrSize
+	if opts.Order != nil {
+		order = opts.Order
+	}
+	if opts.PtrSize != 0 {
+		ptrSize = opts.PtrSize
+	}
+	convert := order != b.Hdr.Order || ptrSize != b.Hdr.PtrSize
+	if !opts.GC && !opts.Deterministic && !convert {
+		return Encode(dst, b)
+	}
+
+	dna, err := b.GetDNA()
+	if err != nil {
+		return err
+	}
+	if opts.GC {
+		stats, err := b.GC(dna)
+		if err != nil {
+			return err
+		}
+		if opts.GCStats != nil {
+			*opts.GCStats = stats
+		}
+	}
+	if convert {
+		conv, unswapped, err := b.Convert(dna, order, ptrSize)
+		if err != nil {
+			return err
+		}
+		if opts.Unswapped != nil {
+			*opts.Unswapped = unswapped
+		}
+		b = conv
+		if dna, err = b.GetDNA(); err != nil {
+			return err
+		}
+	}
+	if opts.Deterministic {
+		// Renumber after conversion, so that the output does not depend on
+		// the byte order and pointer size of the input.
+		if b, err = b.Deterministic(dna); err != nil {
+			return err
+		}
+	}
+	return Encode(dst, b)
+}
+
+// EncodeFile writes b to the file at the given path as EncodeWithOptions does.
+// The blend file is first written to a temporary file in the same directory,
+// which then replaces the file at path; path may thereby be the file b was
+// decoded from, and a partially written file is never left at path.
+func EncodeFile(path string, b *Blend, opts EncodeOptions) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".blend-*.blend")
+	if err != nil {
+		return fmt.Errorf("blend.EncodeFile: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("blend.EncodeFile: %v", err)
+	}
+	if err := EncodeWithOptions(tmp, b, opts); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("blend.EncodeFile: %v", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
 // GetDNA locates, parses and returns the DNA block.
 func (b *Blend) GetDNA() (dna *block.DNA, err error) {
 	for _, blk := range b.Blocks {
@@ -83,7 +268,6 @@ func (b *Blend) GetDNA() (dna *block.DNA, err error) {
 
 		}
 		if blk.Hdr.Code == block.CodeDNA1 {
-			log.Printf("%+v", blk.Hdr)
 			err := blk.ParseBody(nil)
 			if err != nil {
 				return nil, err
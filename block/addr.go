@@ -0,0 +1,107 @@
+package block
+
+import (
+	"math"
+	"sort"
+)
+
+// An AddrIndex resolves memory addresses to the file blocks whose address range
+// [OldAddr, OldAddr+Size) contains them; including addresses of pointers into
+// the middle of blocks, e.g. to a specific element of an array block. An
+// AddrIndex is not updated when its blocks change, and is safe for concurrent
+// use.
+type AddrIndex struct {
+	// addrs maps from memory address to block.
+	addrs map[uint64]*Block
+	// blks holds the blocks sorted by memory address.
+	blks []*Block
+	// ends holds the greatest end address of the address ranges of blks[:i+1]
+	// at index i.
+	ends []uint64
+}
+
+// NewAddrIndex returns an address index of the given blocks. Blocks sharing a
+// memory address are resolved to the last of the blocks.
+func NewAddrIndex(blks []*Block) *AddrIndex {
+	idx := &AddrIndex{
+		addrs: make(map[uint64]*Block, len(blks)),
+		blks:  make([]*Block, len(blks)),
+		ends:  make([]uint64, len(blks)),
+	}
+	copy(idx.blks, blks)
+	for _, blk := range blks {
+		idx.addrs[blk.Hdr.OldAddr] = blk
+	}
+	less := func(i, j int) bool {
+		return idx.blks[i].Hdr.OldAddr < idx.blks[j].Hdr.OldAddr
+	}
+	if !sort.SliceIsSorted(idx.blks, less) {
+		sort.SliceStable(idx.blks, less)
+	}
+	var end uint64
+	for i, blk := range idx.blks {
+		end = max(end, addrEnd(blk))
+		idx.ends[i] = end
+	}
+	return idx
+}
+
+// Blocks returns the blocks of the index, sorted by memory address. The
+// returned slice must not be modified.
+func (idx *AddrIndex) Blocks() []*Block {
+	return idx.blks
+}
+
+// Lookup returns the block starting at the given memory address, and reports
+// whether such a block exists.
+func (idx *AddrIndex) Lookup(addr uint64) (*Block, bool) {
+	blk, ok := idx.addrs[addr]
+	return blk, ok
+}
+
+// A Location locates a memory address within the body of a file block.
+type Location struct {
+	// Block is the block containing the address.
+	Block *Block
+	// Offset is the byte offset of the address within the block body.
+	Offset int64
+	// Index is the index of the structure containing the address, among the
+	// Hdr.Count structures of the block body.
+	Index int
+}
+
+// Resolve returns the location of the given memory address, and reports whether
+// a block containing the address exists. A block starting at the address takes
+// precedence; of overlapping blocks otherwise, the block starting closest to
+// the address is used.
+func (idx *AddrIndex) Resolve(addr uint64) (Location, bool) {
+	if blk, ok := idx.addrs[addr]; ok {
+		return Location{Block: blk}, true
+	}
+	i := sort.Search(len(idx.blks), func(i int) bool {
+		return idx.blks[i].Hdr.OldAddr > addr
+	})
+	for j := i - 1; j >= 0 && idx.ends[j] > addr; j-- {
+		blk := idx.blks[j]
+		if addr >= addrEnd(blk) {
+			continue
+		}
+		loc := Location{Block: blk, Offset: int64(addr - blk.Hdr.OldAddr)}
+		if blk.Hdr.Count > 0 {
+			if elemSize := blk.Hdr.Size / int64(blk.Hdr.Count); elemSize > 0 {
+				loc.Index = int(loc.Offset / elemSize)
+			}
+		}
+		return loc, true
+	}
+	return Location{}, false
+}
+
+// addrEnd returns the end address of the address range of the block, which is
+// clamped to the address space.
+func addrEnd(blk *Block) uint64 {
+	if blk.Hdr.Size < 0 || uint64(blk.Hdr.Size) > math.MaxUint64-blk.Hdr.OldAddr {
+		return math.MaxUint64
+	}
+	return blk.Hdr.OldAddr + uint64(blk.Hdr.Size)
+}
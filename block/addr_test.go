@@ -0,0 +1,64 @@
+package block
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAddrIndex(t *testing.T) {
+	newBlock := func(addr uint64, size int64, count uint32) *Block {
+		return &Block{Hdr: Header{Code: CodeDATA, OldAddr: addr, Size: size, Count: count}}
+	}
+	array := newBlock(0x100, 0x40, 4)
+	inner := newBlock(0x120, 0x10, 1)
+	empty := newBlock(0x200, 0, 1)
+	dup1 := newBlock(0x300, 8, 1)
+	dup2 := newBlock(0x300, 8, 1)
+	last := newBlock(math.MaxUint64-0xF, 0x100, 1)
+	idx := NewAddrIndex([]*Block{last, dup1, empty, inner, array, dup2})
+
+	blks := idx.Blocks()
+	for i := 1; i < len(blks); i++ {
+		if blks[i-1].Hdr.OldAddr > blks[i].Hdr.OldAddr {
+			t.Fatalf("blocks not sorted by address; %#x before %#x", blks[i-1].Hdr.OldAddr, blks[i].Hdr.OldAddr)
+		}
+	}
+	if blk, ok := idx.Lookup(0x300); !ok || blk != dup2 {
+		t.Error("blocks sharing an address not resolved to the last block")
+	}
+	if _, ok := idx.Lookup(0x110); ok {
+		t.Error("lookup of interior address succeeded")
+	}
+
+	golden := []struct {
+		addr  uint64
+		want  Location
+		found bool
+	}{
+		// Exact addresses.
+		{addr: 0x100, want: Location{Block: array}, found: true},
+		{addr: 0x200, want: Location{Block: empty}, found: true},
+		{addr: 0x300, want: Location{Block: dup2}, found: true},
+		// Interior addresses.
+		{addr: 0x110, want: Location{Block: array, Offset: 0x10, Index: 1}, found: true},
+		{addr: 0x13F, want: Location{Block: array, Offset: 0x3F, Index: 3}, found: true},
+		{addr: 0x304, want: Location{Block: dup2, Offset: 4}, found: true},
+		// Address ranges are clamped to the address space.
+		{addr: math.MaxUint64 - 1, want: Location{Block: last, Offset: 0xE}, found: true},
+		// Overlapping blocks resolve to the closest start.
+		{addr: 0x128, want: Location{Block: inner, Offset: 8}, found: true},
+		{addr: 0x130, want: Location{Block: array, Offset: 0x30, Index: 3}, found: true},
+		// Missing addresses.
+		{addr: 0},
+		{addr: 0xFF},
+		{addr: 0x140},
+		{addr: 0x201},
+		{addr: 0x308},
+	}
+	for _, g := range golden {
+		got, ok := idx.Resolve(g.addr)
+		if ok != g.found || got != g.want {
+			t.Errorf("%#x: expected %+v (%v), got %+v (%v)", g.addr, g.want, g.found, got, ok)
+		}
+	}
+}
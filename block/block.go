@@ -1,12 +1,15 @@
 package block
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"reflect"
+	"sync"
 
 	"github.com/mewspring/blend/block/generic"
-	v400 "github.com/mewspring/blend/block/v400"
 )
 
 // A Block contains a header and a type dependent body.
@@ -17,53 +20,300 @@ type Block struct {
 	sr *io.SectionReader
 	r  *Reader
 	w  *Writer
+	// off is the file offset of the block header.
+	off int64
+	// data holds the bytes of the body without copying, if supported by the
+	// source of the block (e.g. a memory mapped file); or nil otherwise.
+	data []byte
+	// indexed verifies the header of a block created from an index entry (see
+	// Reader.BlockAt); or nil if the header was read from the blend file.
+	indexed *indexCheck
+	// walked reports whether the block was read by Reader.Walk, which does not
+	// retain blocks; its body does not count against Limits.MaxAlloc.
+	walked bool
+	// mu serializes parsing of the body.
+	mu sync.Mutex
 }
 
 // ParseBody parses the block body and stores it in blk.Body. It is safe to call
-// ParseBody multiple times on the same block.
-func (blk *Block) ParseBody(dna *DNA) (err error) {
+// ParseBody multiple times on the same block, and concurrently on the same or
+// different blocks.
+//
+// Raw bodies ([]byte) of blocks read from a memory mapped file (see
+// file.OpenMmap) are slices of the read-only mapping, which are only valid
+// until the mapping is closed; use MutableBody to modify raw bodies in place.
+func (blk *Block) ParseBody(dna *DNA) error {
+	blk.mu.Lock()
+	defer blk.mu.Unlock()
 	if blk.Body != nil {
 		// Body has already been parsed.
 		return nil
 	}
 
+	if blk.sr == nil {
+		return fmt.Errorf("Block.ParseBody: block at %#x was not read from a file", blk.Hdr.OldAddr)
+	}
+	if !blk.walked {
+		if err := blk.r.alloc.add(blk.r.Limits, blk.sr.Size()); err != nil {
+			return fmt.Errorf("Block.ParseBody: %v", err)
+		}
+	}
+	body, err := blk.parseBody(dna)
+	if err != nil {
+		return err
+	}
+	blk.Body = body
+	return nil
+}
+
+// Decode parses and returns the block body as ParseBody does, without storing
+// it in blk.Body; e.g. to inspect the pointers of a block without retaining its
+// body. As the body is not retained, it does not count against the total of
+// Limits.MaxAlloc; a single body exceeding Limits.MaxAlloc is still rejected. It
+// is safe to call Decode concurrently with ParseBody.
+func (blk *Block) Decode(dna *DNA) (any, error) {
+	if blk.sr == nil {
+		return nil, fmt.Errorf("Block.Decode: block at %#x was not read from a file", blk.Hdr.OldAddr)
+	}
+	return blk.parseBody(dna)
+}
+
+// parseBody parses and returns the block body.
+func (blk *Block) parseBody(dna *DNA) (body any, err error) {
+	if err := blk.verify(); err != nil {
+		return nil, fmt.Errorf("Block.ParseBody: %v", err)
+	}
+	// Read from the start of the body, regardless of previous reads.
+	sr := io.NewSectionReader(blk.sr, 0, blk.sr.Size())
 	index := blk.Hdr.SDNAIndex
 	if index == 0 {
 		// Parse based on block code.
 		switch blk.Hdr.Code {
 		case CodeDATA:
-			blk.Body, err = io.ReadAll(blk.sr)
-			if err != nil {
-				return err
-			}
+			return blk.readRaw(sr)
 		case CodeDNA1:
-			blk.Body, err = ParseDNA(blk.sr, blk.r.Order)
-			if err != nil {
-				return err
-			}
+			return ParseDNAWithLimits(sr, blk.r.Order, blk.r.Limits)
 		case CodeREND, CodeTEST:
 			/// TODO: implement specific block body parsing for REND and TEST.
-			blk.Body, err = io.ReadAll(blk.sr)
-			if err != nil {
-				return err
-			}
-		default:
-			err = fmt.Errorf("Block.ParseBody: parsing of %q not yet implemented", blk.Hdr.Code)
+			return blk.readRaw(sr)
 		}
-
-		return
+		return nil, fmt.Errorf("Block.ParseBody: parsing of %q not yet implemented", blk.Hdr.Code)
 	}
 
 	// Parse based on SDNA index.
+	if dna == nil || int(index) >= len(dna.Structs) {
+		return nil, fmt.Errorf("Block.ParseBody: SDNA index %d of block at %#x out of range", index, blk.Hdr.OldAddr)
+	}
+	typ := dna.Structs[index].Type
+	if size := int64(dna.TypeSize(typ)) * int64(blk.Hdr.Count); size > blk.Hdr.Size {
+		return nil, fmt.Errorf("Block.ParseBody: %d structures of type %q (%d bytes) exceed body of block at %#x (%d bytes)", blk.Hdr.Count, typ, size, blk.Hdr.OldAddr, blk.Hdr.Size)
+	}
+	if l := blk.r.Limits.resolve(); l.MaxAlloc > 0 && blk.Hdr.Size > l.MaxAlloc {
+		return nil, fmt.Errorf("Block.ParseBody: body of block at %#x (%d bytes) exceeds limit of %d bytes", blk.Hdr.OldAddr, blk.Hdr.Size, l.MaxAlloc)
+	}
+	body, err = blk.r.Parser.ParseStructure(sr, blk.r.Order, blk.r.PtrSize, typ, blk.Hdr.Count)
+	var trailing *generic.TrailingBytesError
+	if errors.As(err, &trailing) {
+		err = blk.r.Diag.report(&Diagnostic{
+			Kind:   DiagTrailingBytes,
+			Offset: blk.off,
+			Code:   blk.Hdr.Code,
+			Type:   typ,
+			Msg:    fmt.Sprintf("%d unread bytes", len(trailing.Data)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Block.ParseBody: %v", err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// readRaw returns the bytes of the block body, without copying if supported by
+// the source of the block.
+func (blk *Block) readRaw(sr io.Reader) ([]byte, error) {
+	if blk.data != nil {
+		return blk.data, nil
+	}
+	return io.ReadAll(sr)
+}
+
+// ParsePrefix parses the structure of the given SDNA type stored at the start
+// of the block body, without parsing the remainder of the body; e.g. the ID
+// structure at the start of ID datablocks. Raw bodies ([]byte) of SDNA blocks
+// are parsed in place of the body stored in the blend file. The body of blk is
+// left untouched. The block must have been read from a file, as the byte order
+// and pointer size of the body are those of the file. As the structure is not
+// retained, it does not count against Limits.MaxAlloc.
+func (blk *Block) ParsePrefix(dna *DNA, typ string) (any, error) {
+	if blk.r == nil {
+		return nil, fmt.Errorf("Block.ParsePrefix: block at %#x was not read from a file", blk.Hdr.OldAddr)
+	}
+	var r io.ReaderAt = blk.sr
+	var bodySize int64
+	if buf, ok := blk.Body.([]byte); ok {
+		r = bytes.NewReader(buf)
+		bodySize = int64(len(buf))
+	} else if blk.sr != nil {
+		if err := blk.verify(); err != nil {
+			return nil, fmt.Errorf("Block.ParsePrefix: %v", err)
+		}
+		bodySize = blk.sr.Size()
+	} else {
+		return nil, fmt.Errorf("Block.ParsePrefix: block at %#x was not read from a file", blk.Hdr.OldAddr)
+	}
+	size := int64(dna.TypeSize(typ))
+	if size == -1 {
+		return nil, fmt.Errorf("Block.ParsePrefix: unable to locate type %q in DNA", typ)
+	}
+	if size > bodySize {
+		return nil, fmt.Errorf("Block.ParsePrefix: %q (%d bytes) exceeds body of block at %#x (%d bytes)", typ, size, blk.Hdr.OldAddr, bodySize)
+	}
+	return blk.r.Parser.ParseStructure(io.NewSectionReader(r, 0, size), blk.r.Order, blk.r.PtrSize, typ, 1)
+}
+
+// RawBody returns a copy of the bytes of the block body as stored in the blend
+// file, regardless of whether the body has been parsed.
+func (blk *Block) RawBody() ([]byte, error) {
+	if blk.sr == nil {
+		return nil, fmt.Errorf("Block.RawBody: block at %#x was not read from a file", blk.Hdr.OldAddr)
+	}
+	if err := blk.verify(); err != nil {
+		return nil, fmt.Errorf("Block.RawBody: %v", err)
+	}
+	buf := make([]byte, blk.sr.Size())
+	if _, err := blk.sr.ReadAt(buf, 0); err != nil {
+		return nil, fmt.Errorf("Block.RawBody: reading block at %#x: %v", blk.Hdr.OldAddr, err)
+	}
+	return buf, nil
+}
+
+// MutableBody returns the raw body ([]byte) of blk for modification in place,
+// parsing the body first if needed. A body which shares memory with the source
+// of the block (e.g. a read-only memory mapped file) is first replaced by a
+// copy. An error is returned if the body is not raw.
+func (blk *Block) MutableBody(dna *DNA) ([]byte, error) {
+	if err := blk.ParseBody(dna); err != nil {
+		return nil, err
+	}
+	blk.mu.Lock()
+	defer blk.mu.Unlock()
+	buf, ok := blk.Body.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("Block.MutableBody: body %T of %q block at %#x is not raw", blk.Body, blk.Hdr.Code, blk.Hdr.OldAddr)
+	}
+	if len(buf) > 0 && len(blk.data) > 0 && &buf[0] == &blk.data[0] {
+		buf = bytes.Clone(buf)
+		blk.Body = buf
+	}
+	return buf, nil
+}
+
+// UpdateHeader recomputes the size and structure count of the block header
+// from the block body. Raw DATA bodies ([]byte) have a count of one; SDNA
+// bodies hold one structure (*T) or a slice of structures ([]*T) of the type
+// given by the SDNA index. Blocks with an unparsed body are left untouched.
+func (blk *Block) UpdateHeader(dna *DNA) error {
+	if blk.Body == nil {
+		return nil
+	}
+	if buf, ok := blk.Body.([]byte); ok {
+		blk.Hdr.Size = int64(len(buf))
+		blk.Hdr.Count = 1
+		return nil
+	}
+	index := int(blk.Hdr.SDNAIndex)
+	if index == 0 || index >= len(dna.Structs) {
+		return fmt.Errorf("Block.UpdateHeader: invalid SDNA index %d of %q block at %#x with body %T", index, blk.Hdr.Code, blk.Hdr.OldAddr, blk.Body)
+	}
 	typ := dna.Structs[index].Type
-	blk.Body, err = blk.r.Parser.ParseStructure(blk.sr, blk.r.Order, blk.r.PtrSize, typ, blk.Hdr.Count)
-	return
+	size := dna.TypeSize(typ)
+	if size == -1 {
+		return fmt.Errorf("Block.UpdateHeader: unable to locate type %q in DNA", typ)
+	}
+	count := 1
+	if v := reflect.ValueOf(blk.Body); v.Kind() == reflect.Slice {
+		count = v.Len()
+	}
+	blk.Hdr.Size = int64(size) * int64(count)
+	blk.Hdr.Count = uint32(count)
+	return nil
 }
 
+// SyncHeader updates the size and structure count of the block header to match
+// the encoded size of the block body for the given pointer size, as needed
+// after the body has been modified. Raw bodies ([]byte) determine the size of
+// the block; SDNA bodies determine both its size and structure count.
+//
+// An error is returned if the body can not be encoded, or if the encoded size
+// of each structure disagrees with the size per structure declared by the
+// header (e.g. if the Go structure definition does not match the DNA). Blocks
+// with an unparsed body are left untouched.
+func (blk *Block) SyncHeader(ptrSize int) error {
+	size, count, err := blk.encodedSize(ptrSize)
+	if err != nil {
+		return err
+	}
+	blk.Hdr.Size = size
+	blk.Hdr.Count = count
+	return nil
+}
+
+// encodedSize returns the encoded size and structure count of the block body,
+// for the given pointer size.
+func (blk *Block) encodedSize(ptrSize int) (size int64, count uint32, err error) {
+	switch body := blk.Body.(type) {
+	case nil:
+		return blk.Hdr.Size, blk.Hdr.Count, nil
+	case []byte:
+		return int64(len(body)), blk.Hdr.Count, nil
+	case *DNA:
+		// The DNA block is written unmodified, unless created by the user.
+		if blk.sr == nil {
+			var n countWriter
+			if err := WriteDNA(&n, binary.LittleEndian, body); err != nil {
+				return 0, 0, err
+			}
+			return int64(n), blk.Hdr.Count, nil
+		}
+		return blk.sr.Size(), blk.Hdr.Count, nil
+	}
+
+	n := 1
+	elem := blk.Body
+	if v := reflect.ValueOf(blk.Body); v.Kind() == reflect.Slice {
+		n = v.Len()
+		if n == 0 {
+			return 0, 0, fmt.Errorf("Block.SyncHeader: empty body %T of %q block at %#x", blk.Body, blk.Hdr.Code, blk.Hdr.OldAddr)
+		}
+		elem = v.Index(0).Interface()
+	}
+	structSize := generic.EncodedSize(elem, ptrSize)
+	if structSize < 0 {
+		return 0, 0, fmt.Errorf("Block.SyncHeader: body %T of %q block at %#x is not fixed-size", blk.Body, blk.Hdr.Code, blk.Hdr.OldAddr)
+	}
+	if blk.Hdr.Count > 0 && blk.Hdr.Size != int64(structSize)*int64(blk.Hdr.Count) {
+		return 0, 0, fmt.Errorf("Block.SyncHeader: %T is encoded in %d bytes, but header of %q block at %#x declares %d structures in %d bytes", elem, structSize, blk.Hdr.Code, blk.Hdr.OldAddr, blk.Hdr.Count, blk.Hdr.Size)
+	}
+	return int64(structSize) * int64(n), uint32(n), nil
+}
+
+// WriteBody writes the block body to dst. An error is returned if the encoded
+// size of the body disagrees with the block header; use SyncHeader to update
+// the header after modifying the body.
 func (blk *Block) WriteBody(dst io.Writer) error {
 	if blk.Body == nil {
 		return fmt.Errorf("nil body cant be written")
 	}
+	size, count, err := blk.encodedSize(blk.w.PtrSize)
+	if err != nil {
+		return err
+	}
+	if size != blk.Hdr.Size || count != blk.Hdr.Count {
+		return fmt.Errorf("Block.WriteBody: body of %q block at %#x is encoded as %d structures in %d bytes, but header declares %d structures in %d bytes", blk.Hdr.Code, blk.Hdr.OldAddr, count, size, blk.Hdr.Count, blk.Hdr.Size)
+	}
 
 	index := blk.Hdr.SDNAIndex
 	if index == 0 {
@@ -75,6 +325,14 @@ func (blk *Block) WriteBody(dst io.Writer) error {
 				return err
 			}
 		case CodeDNA1:
+			if blk.sr == nil {
+				// DNA created by the user (e.g. for another pointer size).
+				dna, ok := blk.Body.(*DNA)
+				if !ok {
+					return fmt.Errorf("Block.WriteBody: invalid DNA body %T of block at %#x", blk.Body, blk.Hdr.OldAddr)
+				}
+				return WriteDNA(dst, blk.w.Order, dna)
+			}
 			// TODO: Re Encode DNA?
 			if _, err := blk.sr.Seek(0, io.SeekStart); err != nil {
 				return fmt.Errorf("failed seeking: %v", err)
@@ -97,8 +355,13 @@ func (blk *Block) WriteBody(dst io.Writer) error {
 		return nil
 	}
 
-	if img, ok := blk.Body.(v400.Image); ok {
-		log.Println(img.Packedfile)
-	}
-	return generic.Write(dst, blk.w.Order, blk.w.PtrSize, blk.Body)
+	return generic.Encode(dst, blk.w.Order, blk.w.PtrSize, blk.Body)
+}
+
+// countWriter counts the bytes written to it.
+type countWriter int64
+
+func (n *countWriter) Write(p []byte) (int, error) {
+	*n += countWriter(len(p))
+	return len(p), nil
 }
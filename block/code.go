@@ -1,7 +1,6 @@
 package block
 
 import (
-	"log"
 	"strings"
 )
 
@@ -17,79 +16,84 @@ func (c Code) String() string {
 }
 
 // TODO: use codegen for this
-func parseCode(code []byte) Code {
+// parseCode parses the given block code, and reports whether it is known.
+func parseCode(code []byte) (c Code, known bool) {
 	switch string(code) {
 	case CodeAR:
-		return CodeAR
+		return CodeAR, true
 	case CodeBR:
-		return CodeBR
+		return CodeBR, true
 	case CodeCA:
-		return CodeCA
+		return CodeCA, true
 	case CodeDATA:
-		return CodeDATA
+		return CodeDATA, true
 	case CodeDNA1:
-		return CodeDNA1
+		return CodeDNA1, true
 	case CodeENDB:
-		return CodeENDB
+		return CodeENDB, true
 	case CodeGLOB:
-		return CodeGLOB
+		return CodeGLOB, true
 	case CodeIM:
-		return CodeIM
+		return CodeIM, true
 	case CodeLA:
-		return CodeLA
+		return CodeLA, true
 	case CodeLS:
-		return CodeLS
+		return CodeLS, true
 	case CodeMA:
-		return CodeMA
+		return CodeMA, true
 	case CodeME:
-		return CodeME
+		return CodeME, true
 	case CodeOB:
-		return CodeOB
+		return CodeOB, true
 	case CodeREND:
-		return CodeREND
+		return CodeREND, true
 	case CodeSC:
-		return CodeSC
+		return CodeSC, true
 	case CodeSN:
-		return CodeSN
+		return CodeSN, true
 	case CodeSR:
-		return CodeSR
+		return CodeSR, true
 	case CodeTE:
-		return CodeTE
+		return CodeTE, true
 	case CodeTEST:
-		return CodeTEST
+		return CodeTEST, true
 	case CodeTX:
-		return CodeTX
+		return CodeTX, true
 	case CodeWM:
-		return CodeWM
+		return CodeWM, true
 	case CodeWO:
-		return CodeWO
+		return CodeWO, true
 	case CodeAC:
-		return CodeAC
+		return CodeAC, true
 	case CodeNT:
-		return CodeNT
+		return CodeNT, true
 	case CodeSO:
-		return CodeSO
+		return CodeSO, true
 	case CodeGR:
-		return CodeGR
+		return CodeGR, true
 	case CodePL:
-		return CodePL
+		return CodePL, true
 	case CodeWS:
-		return CodeWS
+		return CodeWS, true
 	case CodeVF:
-		return CodeVF
+		return CodeVF, true
 	case CodeLI:
-		return CodeLI
+		return CodeLI, true
 	case CodeID:
-		return CodeID
+		return CodeID, true
 	case CodeCU:
-		return CodeCU
+		return CodeCU, true
 	case CodeKE:
-		return CodeKE
-	default:
-		log.Printf("block code not implemented:  %q", code)
+		return CodeKE, true
+	case CodeVO:
+		return CodeVO, true
+	case CodeMC:
+		return CodeMC, true
+	case CodeCF:
+		return CodeCF, true
 	}
 
-	return Code(code)
+	return Code(code), false
 }
 
 // Block codes.
@@ -127,4 +131,7 @@ const (
 	CodeID   = "ID\x00\x00"
 	CodeCU   = "CU\x00\x00"
 	CodeKE   = "KE\x00\x00"
+	CodeVO   = "VO\x00\x00"
+	CodeMC   = "MC\x00\x00"
+	CodeCF   = "CF\x00\x00"
 )
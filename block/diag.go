@@ -0,0 +1,102 @@
+package block
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// A DiagKind specifies the kind of a diagnostic.
+type DiagKind int
+
+// Diagnostic kinds.
+const (
+	// DiagVersion reports a Blender version without generated structure
+	// definitions; the definitions of another version are used instead.
+	DiagVersion DiagKind = iota + 1
+	// DiagUnknownCode reports a block code not known by the block package.
+	DiagUnknownCode
+	// DiagDuplicateAddr reports a memory address shared by blocks with
+	// different headers; i.e. by non-DATA blocks, or by DATA blocks of the same
+	// owner.
+	DiagDuplicateAddr
+	// DiagTrailingBytes reports bytes of a block body left unread after
+	// parsing its structures.
+	DiagTrailingBytes
+)
+
+func (kind DiagKind) String() string {
+	switch kind {
+	case DiagVersion:
+		return "version mismatch"
+	case DiagUnknownCode:
+		return "unknown block code"
+	case DiagDuplicateAddr:
+		return "duplicate address"
+	case DiagTrailingBytes:
+		return "trailing bytes"
+	}
+	return fmt.Sprintf("DiagKind(%d)", int(kind))
+}
+
+// A Diagnostic is a problem encountered while decoding a blend file, which does
+// not prevent decoding unless in strict mode.
+type Diagnostic struct {
+	Kind DiagKind
+	// Offset is the file offset of the block header; or -1 if the diagnostic
+	// does not concern a block.
+	Offset int64
+	// Code is the code of the block.
+	Code Code
+	// Type is the SDNA structure type of the block, if known.
+	Type string
+	// Msg describes the problem.
+	Msg string
+}
+
+func (diag *Diagnostic) Error() string {
+	var parts []string
+	if diag.Offset >= 0 {
+		parts = append(parts, fmt.Sprintf("offset %#x", diag.Offset))
+	}
+	if diag.Code != "" {
+		parts = append(parts, fmt.Sprintf("block %q", diag.Code))
+	}
+	if diag.Type != "" {
+		parts = append(parts, fmt.Sprintf("type %q", diag.Type))
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("%v: %s", diag.Kind, diag.Msg)
+	}
+	return fmt.Sprintf("%v (%s): %s", diag.Kind, strings.Join(parts, ", "), diag.Msg)
+}
+
+// Diagnostics collects the diagnostics of decoding a blend file.
+type Diagnostics struct {
+	// Strict turns trailing bytes and duplicate addresses into errors.
+	Strict bool
+	// Logger receives the diagnostics which are not turned into errors as
+	// warnings; or nil to only record them in List.
+	Logger *slog.Logger
+	// List holds the diagnostics reported so far.
+	List []*Diagnostic
+
+	// mu serializes reports of blocks parsed concurrently.
+	mu sync.Mutex
+}
+
+// report records the given diagnostic, and returns it as an error if it is
+// fatal in strict mode.
+func (d *Diagnostics) report(diag *Diagnostic) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.List = append(d.List, diag)
+	if d.Strict && (diag.Kind == DiagDuplicateAddr || diag.Kind == DiagTrailingBytes) {
+		return diag
+	}
+	if d.Logger != nil {
+		d.Logger.Warn(diag.Msg, "kind", diag.Kind.String(), "offset", diag.Offset, "code", diag.Code.String(), "type", diag.Type)
+	}
+	return nil
+}
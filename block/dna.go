@@ -5,6 +5,8 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 )
 
 // DNA stores information about the various structures contained within a blend
@@ -48,8 +50,126 @@ type DNAField struct {
 	Name string
 }
 
-// ParseDNA parses and returns the body of the "DNA1" block.
+// TypeSize returns the size in bytes of the named type, or -1 if the type is
+// not present in the DNA.
+func (dna *DNA) TypeSize(typ string) int {
+	for i, t := range dna.Types {
+		if t == typ {
+			return dna.TypeSizes[i]
+		}
+	}
+	return -1
+}
+
+// StructIndex returns the SDNA index of the structure of the named type, or -1
+// if the structure is not present in the DNA.
+func (dna *DNA) StructIndex(typ string) int {
+	for i, st := range dna.Structs {
+		if st.Type == typ {
+			return i
+		}
+	}
+	return -1
+}
+
+// FieldOffset returns the offset and size in bytes of the named field of the
+// structure of the given type, for the given pointer size. The name excludes
+// pointer and array notation (e.g. "name" for the "name[66]" field of ID).
+func (dna *DNA) FieldOffset(typ, name string, ptrSize int) (offset, size int, err error) {
+	index := dna.StructIndex(typ)
+	if index == -1 {
+		return 0, 0, fmt.Errorf("DNA.FieldOffset: unable to locate structure %q in DNA", typ)
+	}
+	for _, f := range dna.Structs[index].Fields {
+		fieldName, size, err := dna.fieldSize(f, ptrSize)
+		if err != nil {
+			return 0, 0, err
+		}
+		if fieldName == name {
+			return offset, size, nil
+		}
+		offset += size
+	}
+	return 0, 0, fmt.Errorf("DNA.FieldOffset: unable to locate field %q of structure %q", name, typ)
+}
+
+// fieldSize returns the bare name and the size in bytes of the given structure
+// field, for the given pointer size.
+func (dna *DNA) fieldSize(f DNAField, ptrSize int) (name string, size int, err error) {
+	name, ptrLevel, arrayLen, err := f.Decl()
+	if err != nil {
+		return "", 0, err
+	}
+	if ptrLevel > 0 {
+		size = ptrSize
+	} else if size = dna.TypeSize(f.Type); size == -1 {
+		return "", 0, fmt.Errorf("DNA.fieldSize: unable to locate type %q of field %q", f.Type, f.Name)
+	}
+	return name, size * arrayLen, nil
+}
+
+// Decl returns the bare name, pointer level and total array length of the
+// field; e.g. "mat", 2 and 1 for "**mat", and "clip", 0 and 24 for
+// "clip[6][4]". Function pointers have a pointer level of one.
+func (f DNAField) Decl() (name string, ptrLevel, arrayLen int, err error) {
+	s := f.Name
+	// Function pointer.
+	if strings.HasPrefix(s, "(*") {
+		end := strings.Index(s, ")")
+		if end == -1 {
+			return "", 0, 0, fmt.Errorf("DNAField.Decl: unmatched opening parenthesis in %q", s)
+		}
+		return s[2:end], 1, 1, nil
+	}
+	name = strings.TrimLeft(s, "*")
+	ptrLevel = len(s) - len(name)
+	arrayLen = 1
+	pos := strings.Index(name, "[")
+	if pos == -1 {
+		return name, ptrLevel, arrayLen, nil
+	}
+	dims := name[pos:]
+	name = name[:pos]
+	for len(dims) > 0 {
+		end := strings.Index(dims, "]")
+		if dims[0] != '[' || end == -1 {
+			return "", 0, 0, fmt.Errorf("DNAField.Decl: invalid array notation in %q", s)
+		}
+		n, err := strconv.Atoi(dims[1:end])
+		if err != nil {
+			return "", 0, 0, fmt.Errorf("DNAField.Decl: invalid array length in %q: %v", s, err)
+		}
+		arrayLen *= n
+		dims = dims[end+1:]
+	}
+	return name, ptrLevel, arrayLen, nil
+}
+
+// IsFuncPointer reports whether the field is a function pointer (e.g.
+// "(*free)()"). makesdna stores pointers to arrays in the same notation (e.g.
+// "float (*disps)[3]" as "(*disps)()"); as function pointers of the DNA return
+// void or bool, other types denote pointers to arrays.
+func (f DNAField) IsFuncPointer() bool {
+	if !strings.HasPrefix(f.Name, "(*") {
+		return false
+	}
+	end := strings.Index(f.Name, ")")
+	if end == -1 || !strings.HasPrefix(f.Name[end+1:], "(") {
+		return false
+	}
+	return f.Type == "void" || f.Type == "bool"
+}
+
+// ParseDNA parses and returns the body of the "DNA1" block, using the default
+// resource limits.
 func ParseDNA(r io.Reader, order binary.ByteOrder) (body *DNA, err error) {
+	return ParseDNAWithLimits(r, order, Limits{})
+}
+
+// ParseDNAWithLimits parses and returns the body of the "DNA1" block. The
+// number of names, types, structures and fields are bounded by the given
+// limits, and every type and name index is checked.
+func ParseDNAWithLimits(r io.Reader, order binary.ByteOrder, limits Limits) (body *DNA, err error) {
 	br := bufio.NewReader(r)
 	rawID := make([]byte, 4)
 
@@ -74,12 +194,10 @@ func ParseDNA(r io.Reader, order binary.ByteOrder) (body *DNA, err error) {
 	}
 
 	// Name count.
-	var x32 int32
-	err = binary.Read(br, order, &x32)
+	nameCount, err := readCount(br, order, limits, "name")
 	if err != nil {
 		return nil, err
 	}
-	nameCount := int(x32)
 
 	// Names.
 	body = new(DNA)
@@ -88,7 +206,7 @@ func ParseDNA(r io.Reader, order binary.ByteOrder) (body *DNA, err error) {
 	for i := range body.Names {
 		buf, err := br.ReadSlice(0x00)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("block.ParseDNA: reading name %d: %v", i, err)
 		}
 		total += len(buf)
 		body.Names[i] = string(buf[:len(buf)-1])
@@ -109,11 +227,10 @@ func ParseDNA(r io.Reader, order binary.ByteOrder) (body *DNA, err error) {
 	}
 
 	// Type count.
-	err = binary.Read(br, order, &x32)
+	typeCount, err := readCount(br, order, limits, "type")
 	if err != nil {
 		return nil, err
 	}
-	typeCount := int(x32)
 
 	// Types.
 	body.Types = make([]string, typeCount)
@@ -121,7 +238,7 @@ func ParseDNA(r io.Reader, order binary.ByteOrder) (body *DNA, err error) {
 	for i := range body.Types {
 		buf, err := br.ReadSlice(0x00)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("block.ParseDNA: reading type %d: %v", i, err)
 		}
 		total += len(buf)
 		body.Types[i] = string(buf[:len(buf)-1])
@@ -141,8 +258,9 @@ func ParseDNA(r io.Reader, order binary.ByteOrder) (body *DNA, err error) {
 		return nil, fmt.Errorf("block.ParseDNA: invalid type length identifier %q", lenID)
 	}
 
-	// Type sizes.
-	var x16 int16
+	// Type sizes. Sizes and indices are stored as 16-bit integers, which are
+	// never negative; they are thus decoded as unsigned.
+	var x16 uint16
 	body.TypeSizes = make([]int, typeCount)
 	total = 0
 	for i := range body.TypeSizes {
@@ -169,21 +287,30 @@ func ParseDNA(r io.Reader, order binary.ByteOrder) (body *DNA, err error) {
 	}
 
 	// Structure count.
-	err = binary.Read(br, order, &x32)
+	structCount, err := readCount(br, order, limits, "structure")
 	if err != nil {
 		return nil, err
 	}
-	structCount := int(x32)
+
+	// readType reads a type index, and returns the type name.
+	readType := func() (string, error) {
+		if err := binary.Read(br, order, &x16); err != nil {
+			return "", err
+		}
+		if int(x16) >= len(body.Types) {
+			return "", fmt.Errorf("block.ParseDNA: type index %d out of range [0, %d)", x16, len(body.Types))
+		}
+		return body.Types[x16], nil
+	}
 
 	// Structures.
 	body.Structs = make([]DNAStruct, structCount)
 	for i := range body.Structs {
 		// Structure type.
-		err = binary.Read(br, order, &x16)
+		body.Structs[i].Type, err = readType()
 		if err != nil {
 			return nil, err
 		}
-		body.Structs[i].Type = body.Types[x16]
 
 		// Field count.
 		err = binary.Read(br, order, &x16)
@@ -191,22 +318,27 @@ func ParseDNA(r io.Reader, order binary.ByteOrder) (body *DNA, err error) {
 			return nil, err
 		}
 		fieldCount := int(x16)
+		if err := limits.checkDNAEntries("field", fieldCount); err != nil {
+			return nil, fmt.Errorf("block.ParseDNA: structure %q: %v", body.Structs[i].Type, err)
+		}
 		body.Structs[i].Fields = make([]DNAField, fieldCount)
 
 		// Fields.
 		for j := range body.Structs[i].Fields {
 			// Field type.
-			err = binary.Read(br, order, &x16)
+			body.Structs[i].Fields[j].Type, err = readType()
 			if err != nil {
 				return nil, err
 			}
-			body.Structs[i].Fields[j].Type = body.Types[x16]
 
 			// Field name.
 			err = binary.Read(br, order, &x16)
 			if err != nil {
 				return nil, err
 			}
+			if int(x16) >= len(body.Names) {
+				return nil, fmt.Errorf("block.ParseDNA: name index %d out of range [0, %d)", x16, len(body.Names))
+			}
 			body.Structs[i].Fields[j].Name = body.Names[x16]
 		}
 	}
@@ -214,6 +346,20 @@ func ParseDNA(r io.Reader, order binary.ByteOrder) (body *DNA, err error) {
 	return body, nil
 }
 
+// readCount reads a 32-bit count of DNA entries of the given kind, and checks
+// it against the limits.
+func readCount(r io.Reader, order binary.ByteOrder, limits Limits, kind string) (int, error) {
+	var x32 int32
+	if err := binary.Read(r, order, &x32); err != nil {
+		return 0, err
+	}
+	n := int(x32)
+	if err := limits.checkDNAEntries(kind, n); err != nil {
+		return 0, fmt.Errorf("block.ParseDNA: %v", err)
+	}
+	return n, nil
+}
+
 // align advances the reader so that it is aligned with n, were total
 // corresponds to the number of bytes read so far.
 func align(r io.Reader, total int, n int) (err error) {
@@ -226,3 +372,82 @@ func align(r io.Reader, total int, n int) (err error) {
 	}
 	return nil
 }
+
+// WriteDNA writes dna to w as the body of a "DNA1" block, using the given byte
+// order. It is the inverse of ParseDNA.
+func WriteDNA(w io.Writer, order binary.ByteOrder, dna *DNA) error {
+	if len(dna.TypeSizes) != len(dna.Types) {
+		return fmt.Errorf("block.WriteDNA: type count (%d) and type size count (%d) mismatch", len(dna.Types), len(dna.TypeSizes))
+	}
+	nameIndex := make(map[string]int)
+	for i := len(dna.Names) - 1; i >= 0; i-- {
+		nameIndex[dna.Names[i]] = i
+	}
+	typeIndex := make(map[string]int)
+	for i := len(dna.Types) - 1; i >= 0; i-- {
+		typeIndex[dna.Types[i]] = i
+	}
+
+	bw := bufio.NewWriter(w)
+	var total int
+	put := func(data any) {
+		binary.Write(bw, order, data)
+		total += binary.Size(data)
+	}
+	putString := func(s string) {
+		bw.WriteString(s)
+		bw.WriteByte(0)
+		total += len(s) + 1
+	}
+	pad := func() {
+		for ; total%4 != 0; total++ {
+			bw.WriteByte(0)
+		}
+	}
+
+	bw.WriteString("SDNANAME")
+	total += 8
+	put(int32(len(dna.Names)))
+	for _, name := range dna.Names {
+		putString(name)
+	}
+	pad()
+
+	bw.WriteString("TYPE")
+	total += 4
+	put(int32(len(dna.Types)))
+	for _, typ := range dna.Types {
+		putString(typ)
+	}
+	pad()
+
+	bw.WriteString("TLEN")
+	total += 4
+	for _, size := range dna.TypeSizes {
+		put(int16(size))
+	}
+	pad()
+
+	bw.WriteString("STRC")
+	total += 4
+	put(int32(len(dna.Structs)))
+	for _, st := range dna.Structs {
+		index, ok := typeIndex[st.Type]
+		if !ok {
+			return fmt.Errorf("block.WriteDNA: unable to locate type %q of structure", st.Type)
+		}
+		put([2]int16{int16(index), int16(len(st.Fields))})
+		for _, f := range st.Fields {
+			typ, ok := typeIndex[f.Type]
+			if !ok {
+				return fmt.Errorf("block.WriteDNA: unable to locate type %q of field %q in %q", f.Type, f.Name, st.Type)
+			}
+			name, ok := nameIndex[f.Name]
+			if !ok {
+				return fmt.Errorf("block.WriteDNA: unable to locate name %q of field in %q", f.Name, st.Type)
+			}
+			put([2]int16{int16(typ), int16(name)})
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,88 @@
+package block
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/mewspring/blend/block/generic"
+)
+
+// fuzzOrder returns the byte order selected by big.
+func fuzzOrder(big bool) binary.ByteOrder {
+	if big {
+		return binary.BigEndian
+	}
+	return binary.LittleEndian
+}
+
+// fuzzPtrSize returns the pointer size selected by ptr8.
+func fuzzPtrSize(ptr8 bool) int {
+	if ptr8 {
+		return 8
+	}
+	return 4
+}
+
+func FuzzParseHeader(f *testing.F) {
+	f.Add([]byte("DATA\xe0\x00\x00\x00\x88\x5e\x9d\x04\x00\x00\x00\x00\xf8\x00\x00\x00\x0e\x00\x00\x00"), true, false)
+	f.Add([]byte("ENDB\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00"), false, true)
+	f.Fuzz(func(t *testing.T, data []byte, ptr8, big bool) {
+		r := NewReader(fuzzOrder(big), fuzzPtrSize(ptr8), 400)
+		blk, err := r.ReadBlock(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if blk.Hdr.Size > DefaultLimits.MaxBlockSize {
+			t.Fatalf("block size %d exceeds limit", blk.Hdr.Size)
+		}
+	})
+}
+
+func FuzzParseDNA(f *testing.F) {
+	dna := &DNA{
+		Names:     []string{"*next", "*prev", "name[66]", "flag", "(*free)()"},
+		Types:     []string{"char", "short", "int", "void", "Link", "ID"},
+		TypeSizes: []int{1, 2, 4, 0, 16, 82},
+		Structs: []DNAStruct{
+			{Type: "Link", Fields: []DNAField{{Type: "Link", Name: "*next"}, {Type: "Link", Name: "*prev"}}},
+			{Type: "ID", Fields: []DNAField{{Type: "char", Name: "name[66]"}, {Type: "short", Name: "flag"}, {Type: "void", Name: "(*free)()"}}},
+		},
+	}
+	for _, big := range []bool{false, true} {
+		buf := new(bytes.Buffer)
+		if err := WriteDNA(buf, fuzzOrder(big), dna); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf.Bytes(), big)
+	}
+	f.Fuzz(func(t *testing.T, data []byte, big bool) {
+		dna, err := ParseDNA(bytes.NewReader(data), fuzzOrder(big))
+		if err != nil {
+			return
+		}
+		// A parsed DNA must be encodable.
+		if err := WriteDNA(new(bytes.Buffer), fuzzOrder(big), dna); err != nil {
+			t.Fatalf("unable to encode parsed DNA: %v", err)
+		}
+	})
+}
+
+func FuzzParseStructure(f *testing.F) {
+	f.Add(make([]byte, 82), "ID", uint32(1), true, false)
+	f.Add(make([]byte, 32), "ListBase", uint32(2), true, true)
+	f.Add(make([]byte, 24), "vec3f", uint32(2), false, false)
+	f.Fuzz(func(t *testing.T, data []byte, typ string, count uint32, ptr8, big bool) {
+		parser := Versions[400]
+		body, err := parser.ParseStructure(bytes.NewReader(data), fuzzOrder(big), fuzzPtrSize(ptr8), typ, count)
+		if err != nil {
+			return
+		}
+		// A parsed body must be encodable.
+		if body != nil {
+			if err := generic.Encode(new(bytes.Buffer), fuzzOrder(big), fuzzPtrSize(ptr8), body); err != nil {
+				t.Fatalf("unable to encode parsed body %T: %v", body, err)
+			}
+		}
+	})
+}
@@ -132,9 +132,9 @@ func Read(r io.Reader, order binary.ByteOrder, ptrSize int, data any) error {
 	switch v.Kind() {
 	case reflect.Pointer:
 		v = v.Elem()
-		size = dataSize(v)
+		size = dataSize(v, ptrSize)
 	case reflect.Slice:
-		size = dataSize(v)
+		size = dataSize(v, ptrSize)
 	}
 	if size < 0 {
 		return errors.New("binary.Read: invalid type " + reflect.TypeOf(data).String())
@@ -265,7 +265,7 @@ func Write(w io.Writer, order binary.ByteOrder, ptrSize int, data any) error {
 
 	// Fallback to reflect-based encoding.
 	v := reflect.Indirect(reflect.ValueOf(data))
-	size := dataSize(v)
+	size := dataSize(v, ptrSize)
 	if size < 0 {
 		return errors.New("binary.Write: some values are not fixed-sized in type " + reflect.TypeOf(data).String())
 	}
@@ -278,36 +278,56 @@ func Write(w io.Writer, order binary.ByteOrder, ptrSize int, data any) error {
 
 // Size returns how many bytes Write would generate to encode the value v, which
 // must be a fixed-size value or a slice of fixed-size values, or a pointer to such data.
+// Pointer fields are assumed to be 8 bytes; use EncodedSize for other pointer sizes.
 // If v is neither of these, Size returns -1.
 func Size(v any) int {
-	return dataSize(reflect.Indirect(reflect.ValueOf(v)))
+	return EncodedSize(v, 8)
 }
 
-var structSize sync.Map // map[reflect.Type]int
+// EncodedSize returns how many bytes Write would generate to encode the value v
+// with the given pointer size. In addition to the values accepted by Size, v
+// may be a slice of pointers to fixed-size values, as returned by ReadT for
+// counts other than one. If v is not of an acceptable type, EncodedSize
+// returns -1.
+func EncodedSize(v any, ptrSize int) int {
+	return dataSize(reflect.Indirect(reflect.ValueOf(v)), ptrSize)
+}
+
+// sizeKey is the key of cached structure sizes.
+type sizeKey struct {
+	t       reflect.Type
+	ptrSize int
+}
+
+var structSize sync.Map // map[sizeKey]int
 
 // dataSize returns the number of bytes the actual data represented by v occupies in memory.
 // For compound structures, it sums the sizes of the elements. Thus, for instance, for a slice
 // it returns the length of the slice times the element size and does not count the memory
 // occupied by the header. If the type of v is not acceptable, dataSize returns -1.
-func dataSize(v reflect.Value) int {
+func dataSize(v reflect.Value, ptrSize int) int {
 	switch v.Kind() {
 	case reflect.Slice:
-		if s := sizeof(v.Type().Elem()); s >= 0 {
+		elem := v.Type().Elem()
+		if elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+		if s := sizeof(elem, ptrSize); s >= 0 {
 			return s * v.Len()
 		}
 
 	case reflect.Struct:
-		t := v.Type()
-		if size, ok := structSize.Load(t); ok {
+		key := sizeKey{t: v.Type(), ptrSize: ptrSize}
+		if size, ok := structSize.Load(key); ok {
 			return size.(int)
 		}
-		size := sizeof(t)
-		structSize.Store(t, size)
+		size := sizeof(key.t, ptrSize)
+		structSize.Store(key, size)
 		return size
 
 	default:
 		if v.IsValid() {
-			return sizeof(v.Type())
+			return sizeof(v.Type(), ptrSize)
 		}
 	}
 
@@ -315,17 +335,22 @@ func dataSize(v reflect.Value) int {
 }
 
 // sizeof returns the size >= 0 of variables for the given type or -1 if the type is not acceptable.
-func sizeof(t reflect.Type) int {
+// Struct fields tagged `bin:"ptrSize"` occupy ptrSize bytes.
+func sizeof(t reflect.Type, ptrSize int) int {
 	switch t.Kind() {
 	case reflect.Array:
-		if s := sizeof(t.Elem()); s >= 0 {
+		if s := sizeof(t.Elem(), ptrSize); s >= 0 {
 			return s * t.Len()
 		}
 
 	case reflect.Struct:
 		sum := 0
 		for i, n := 0, t.NumField(); i < n; i++ {
-			s := sizeof(t.Field(i).Type)
+			if t.Field(i).Tag.Get("bin") == "ptrSize" {
+				sum += ptrSize
+				continue
+			}
+			s := sizeof(t.Field(i).Type, ptrSize)
 			if s < 0 {
 				return -1
 			}
@@ -468,6 +493,13 @@ func (d *decoder) value(v reflect.Value) {
 			d.value(v.Index(i))
 		}
 
+	case reflect.Pointer:
+		// Elements of slices of pointers.
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		d.value(v.Elem())
+
 	case reflect.Bool:
 		v.SetBool(d.bool())
 
@@ -541,6 +573,14 @@ func (e *encoder) value(v reflect.Value) {
 			e.value(v.Index(i))
 		}
 
+	case reflect.Pointer:
+		// Elements of slices of pointers; nil elements are zero-valued.
+		if v.IsNil() {
+			e.skip(reflect.Zero(v.Type().Elem()))
+		} else {
+			e.value(v.Elem())
+		}
+
 	case reflect.Bool:
 		e.bool(v.Bool())
 
@@ -591,11 +631,11 @@ func (e *encoder) value(v reflect.Value) {
 }
 
 func (d *decoder) skip(v reflect.Value) {
-	d.offset += dataSize(v)
+	d.offset += dataSize(v, d.ptrSize)
 }
 
 func (e *encoder) skip(v reflect.Value) {
-	n := dataSize(v)
+	n := dataSize(v, e.ptrSize)
 	zero := e.buf[e.offset : e.offset+n]
 	for i := range zero {
 		zero[i] = 0
@@ -0,0 +1,127 @@
+package generic
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// A Decoder is a structure with a generated decoder (see blendef), which decodes
+// the structure without reflection.
+type Decoder interface {
+	// DecodeFrom decodes the structure from buf, and returns the number of bytes
+	// read. The length of buf must be at least the encoded size of the
+	// structure.
+	DecodeFrom(buf []byte, order binary.ByteOrder, ptrSize int) int
+}
+
+// An Encoder is a structure with a generated encoder (see blendef), which
+// encodes the structure without reflection.
+type Encoder interface {
+	// EncodeTo encodes the structure to buf, and returns the number of bytes
+	// written. The length of buf must be at least the encoded size of the
+	// structure.
+	EncodeTo(buf []byte, order binary.ByteOrder, ptrSize int) int
+}
+
+// DecodePointer decodes a pointer of the given size from buf.
+func DecodePointer(buf []byte, order binary.ByteOrder, ptrSize int) uint64 {
+	if ptrSize == 4 {
+		return uint64(order.Uint32(buf))
+	}
+	return order.Uint64(buf)
+}
+
+// EncodePointer encodes a pointer of the given size to buf.
+func EncodePointer(buf []byte, order binary.ByteOrder, ptrSize int, addr uint64) {
+	if ptrSize == 4 {
+		order.PutUint32(buf, uint32(addr))
+		return
+	}
+	order.PutUint64(buf, addr)
+}
+
+// DecodeT reads count structures of type T from r using the generated decoder
+// of T. It is the reflection-free counterpart of ReadT, and returns a *T if
+// count is one, and a []*T otherwise. The structures are only allocated once
+// their data has been read, or is known to be available from the Size of r.
+func DecodeT[T any, P interface {
+	*T
+	Decoder
+}](r io.Reader, order binary.ByteOrder, ptrSize int, count uint32) (any, error) {
+	size := EncodedSize(new(T), ptrSize)
+	if size <= 0 {
+		return nil, fmt.Errorf("generic.DecodeT: invalid type %T", new(T))
+	}
+	n := int64(size) * int64(count)
+	var buf []byte
+	if sr, ok := r.(interface{ Size() int64 }); ok {
+		// Guard against counts exceeding the data of sized readers (e.g.
+		// io.SectionReader), before allocating.
+		if n > sr.Size() {
+			return nil, fmt.Errorf("generic.DecodeT: %d structures of %T (%d bytes each) exceed %d bytes", count, new(T), size, sr.Size())
+		}
+		buf = make([]byte, n)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+	} else {
+		// Grow the buffer as the data of readers of unknown size is read, so
+		// that a corrupt count does not cause a large allocation.
+		var err error
+		if buf, err = io.ReadAll(io.LimitReader(r, n)); err != nil {
+			return nil, err
+		}
+		if int64(len(buf)) < n {
+			return nil, io.ErrUnexpectedEOF
+		}
+	}
+	if count == 1 {
+		body := new(T)
+		P(body).DecodeFrom(buf, order, ptrSize)
+		return body, nil
+	}
+	elems := make([]T, count)
+	bodies := make([]*T, count)
+	for i := range bodies {
+		bodies[i] = &elems[i]
+		P(bodies[i]).DecodeFrom(buf[i*size:], order, ptrSize)
+	}
+	return bodies, nil
+}
+
+// Encode writes the binary representation of data to w, using the generated
+// encoders of data if available; i.e. if data is an Encoder or a slice of
+// Encoders. Any other data is written using Write.
+func Encode(w io.Writer, order binary.ByteOrder, ptrSize int, data any) error {
+	if enc, ok := data.(Encoder); ok {
+		size := EncodedSize(data, ptrSize)
+		if size < 0 {
+			return fmt.Errorf("generic.Encode: invalid type %T", data)
+		}
+		buf := make([]byte, size)
+		enc.EncodeTo(buf, order, ptrSize)
+		_, err := w.Write(buf)
+		return err
+	}
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice || !v.Type().Elem().Implements(reflect.TypeOf((*Encoder)(nil)).Elem()) {
+		return Write(w, order, ptrSize, data)
+	}
+	size := EncodedSize(data, ptrSize)
+	if size < 0 {
+		return fmt.Errorf("generic.Encode: invalid type %T", data)
+	}
+	buf := make([]byte, size)
+	off := 0
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		if elem.Kind() == reflect.Pointer && elem.IsNil() {
+			return fmt.Errorf("generic.Encode: nil element %d of %T", i, data)
+		}
+		off += elem.Interface().(Encoder).EncodeTo(buf[off:], order, ptrSize)
+	}
+	_, err := w.Write(buf)
+	return err
+}
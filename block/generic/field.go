@@ -0,0 +1,136 @@
+package generic
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Field returns the named field of the structure pointed to by body. The zero
+// Value is returned if body is not a pointer to a structure or if the structure
+// has no such field.
+//
+// Field makes it possible to access structures of any Blender version (e.g.
+// v305.Object and v400.Object) by their common field names.
+func Field(body any, name string) reflect.Value {
+	v := reflect.ValueOf(body)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return reflect.Value{}
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return v.FieldByName(name)
+}
+
+// FieldAddr returns the address stored in the named BlockPointer field of the
+// structure pointed to by body. Zero is returned if no such field exists.
+func FieldAddr(body any, name string) uint64 {
+	return PointerAddr(Field(body, name))
+}
+
+// SetFieldAddr stores addr in the named BlockPointer field of the structure
+// pointed to by body.
+func SetFieldAddr(body any, name string, addr uint64) error {
+	v := Field(body, name)
+	if !IsPointer(v) {
+		return fmt.Errorf("generic.SetFieldAddr: %T has no pointer field %q", body, name)
+	}
+	v.Field(0).SetUint(addr)
+	return nil
+}
+
+// PointerAddr returns the address stored in v, which must be a BlockPointer.
+// Zero is returned for any other value.
+func PointerAddr(v reflect.Value) uint64 {
+	if !IsPointer(v) {
+		return 0
+	}
+	return v.Field(0).Uint()
+}
+
+// IsPointer reports whether v is a BlockPointer. Function pointers
+// (FuncPointer) never refer to blocks, and are not reported.
+func IsPointer(v reflect.Value) bool {
+	if !v.IsValid() || v.Kind() != reflect.Struct || v.NumField() != 1 || v.Type() == funcPointerType {
+		return false
+	}
+	return v.Type().Field(0).Tag.Get("bin") == "ptrSize"
+}
+
+// funcPointerType is the type of function pointers.
+var funcPointerType = reflect.TypeOf(FuncPointer{})
+
+// PointerTarget returns the target type of the BlockPointer type t (e.g. the
+// type **Material of BlockPointer[**Material]), or nil if t is not a
+// BlockPointer type.
+func PointerTarget(t reflect.Type) reflect.Type {
+	if t.Kind() != reflect.Struct || t.NumField() != 1 || t.Field(0).Tag.Get("bin") != "ptrSize" {
+		return nil
+	}
+	m, ok := t.MethodByName("Data")
+	if !ok || m.Type.NumOut() != 1 {
+		return nil
+	}
+	return m.Type.Out(0)
+}
+
+// IsPointerArray reports whether the BlockPointer type t points to an array of
+// pointers (e.g. BlockPointer[**Material]), as stored in raw DATA blocks.
+func IsPointerArray(t reflect.Type) bool {
+	target := PointerTarget(t)
+	return target != nil && target.Kind() == reflect.Pointer && target.Elem().Kind() == reflect.Pointer
+}
+
+// FieldString returns the NUL-terminated string stored in the named byte array
+// field of the structure pointed to by body. The empty string is returned if no
+// such field exists.
+func FieldString(body any, name string) string {
+	v := Field(body, name)
+	if !v.IsValid() || v.Kind() != reflect.Array || v.Type().Elem().Kind() != reflect.Uint8 {
+		return ""
+	}
+	return CString(v.Slice(0, v.Len()).Bytes())
+}
+
+// SetFieldString stores s as a NUL-terminated string in the named byte array
+// field of the structure pointed to by body. The remainder of the array is
+// zeroed. An error is returned if s and its NUL terminator do not fit.
+func SetFieldString(body any, name string, s string) error {
+	v := Field(body, name)
+	if !v.IsValid() || v.Kind() != reflect.Array || v.Type().Elem().Kind() != reflect.Uint8 {
+		return fmt.Errorf("generic.SetFieldString: %T has no string field %q", body, name)
+	}
+	if len(s) >= v.Len() {
+		return fmt.Errorf("generic.SetFieldString: %q exceeds %d bytes of field %q", s, v.Len()-1, name)
+	}
+	if strings.IndexByte(s, 0) != -1 {
+		return fmt.Errorf("generic.SetFieldString: %q contains NUL byte", s)
+	}
+	buf := v.Slice(0, v.Len()).Bytes()
+	n := copy(buf, s)
+	clear(buf[n:])
+	return nil
+}
+
+// CString returns the NUL-terminated string stored in s.
+func CString(s []uint8) string {
+	for i, c := range s {
+		if c == 0 {
+			return string(s[:i])
+		}
+	}
+	return string(s)
+}
+
+// IDName returns the name of the ID datablock pointed to by body, including its
+// two-letter code prefix (e.g. "OBCube"). The empty string is returned if body
+// has no ID.
+func IDName(body any) string {
+	id := Field(body, "Id")
+	if !id.IsValid() {
+		return ""
+	}
+	return FieldString(id.Addr().Interface(), "Name")
+}
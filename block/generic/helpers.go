@@ -2,9 +2,8 @@ package generic
 
 import (
 	"encoding/binary"
-	"encoding/hex"
+	"fmt"
 	"io"
-	"log"
 )
 
 func readOneT[T any](r io.Reader, order binary.ByteOrder, ptrSize int) (_ *T, err error) {
@@ -31,6 +30,21 @@ func ReadT[T any](r io.Reader, order binary.ByteOrder, ptrSize int, count uint32
 	return readSliceT[T](r, order, ptrSize, count)
 }
 
+// A TrailingBytesError reports bytes left unread after parsing the structures
+// of a block body.
+type TrailingBytesError struct {
+	// Type is the structure type of the block body.
+	Type string
+	// Data holds the unread bytes.
+	Data []byte
+}
+
+func (e *TrailingBytesError) Error() string {
+	return fmt.Sprintf("generic.EnsureAllRead: %d unread bytes in %q", len(e.Data), e.Type)
+}
+
+// EnsureAllRead reads the remainder of r, and returns a *TrailingBytesError if
+// any bytes were left unread.
 func EnsureAllRead(r io.Reader, typ string) error {
 	buf, err := io.ReadAll(r)
 	if err != nil {
@@ -41,8 +55,5 @@ func EnsureAllRead(r io.Reader, typ string) error {
 		return nil
 	}
 
-	log.Printf("%d unread bytes in %q.", len(buf), typ)
-	log.Println(hex.Dump(buf))
-
-	return nil
+	return &TrailingBytesError{Type: typ, Data: buf}
 }
@@ -13,3 +13,13 @@ func (p BlockPointer[T]) Data() T {
 func (p BlockPointer[T]) Valid() bool {
 	return p.Addr != 0
 }
+
+// FuncPointer is the memory address of a function when it was written to disk.
+// Function pointers are only meaningful at runtime, and are therefore opaque.
+type FuncPointer struct {
+	Addr uint64 `bin:"ptrSize"`
+}
+
+func (p FuncPointer) Valid() bool {
+	return p.Addr != 0
+}
@@ -32,6 +32,12 @@ type Header struct {
 //	// 20-23   count        (0x0000000E = 14)
 const headerSizeWithoutPtr = 16
 
+// HeaderSize returns the size in bytes of a file block header, for the given
+// pointer size.
+func HeaderSize(ptrSize int) int {
+	return headerSizeWithoutPtr + ptrSize
+}
+
 func (r *Reader) ParseHeader(src readSeekerAt) (hdr Header, _ error) {
 	// Block code.
 	header := make([]byte, headerSizeWithoutPtr+r.PtrSize)
@@ -40,7 +46,7 @@ func (r *Reader) ParseHeader(src readSeekerAt) (hdr Header, _ error) {
 	}
 
 	var offset int
-	hdr.Code = parseCode(header[:4])
+	hdr.Code, _ = parseCode(header[:4])
 	offset += 4
 
 	// Block size.
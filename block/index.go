@@ -0,0 +1,58 @@
+package block
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// An IndexEntry locates a file block within a blend file, so that the block may
+// be recreated without reading its header; see Reader.BlockAt.
+type IndexEntry struct {
+	// Offset is the file offset of the block header.
+	Offset int64
+	// Hdr is the header of the block.
+	Hdr Header
+}
+
+// IndexEntry returns the index entry locating the block, and reports whether
+// the block was read from a file.
+func (blk *Block) IndexEntry() (IndexEntry, bool) {
+	if blk.sr == nil {
+		return IndexEntry{}, false
+	}
+	return IndexEntry{Offset: blk.off, Hdr: blk.Hdr}, true
+}
+
+// An indexCheck verifies the header of a block created from an index entry
+// against the header stored in the blend file, when the block is first read.
+type indexCheck struct {
+	// want is the header of the index entry.
+	want Header
+	// sr reads the header stored in the blend file.
+	sr   *io.SectionReader
+	once sync.Once
+	err  error
+}
+
+// verify checks that the header of a block created from an index entry matches
+// the header stored in the blend file, and returns an error if the index is
+// stale. The header is only read the first time verify is called; blocks read
+// from the blend file are not checked.
+func (blk *Block) verify() error {
+	c := blk.indexed
+	if c == nil {
+		return nil
+	}
+	c.once.Do(func() {
+		hdr, err := blk.r.ParseHeader(c.sr)
+		if err != nil {
+			c.err = fmt.Errorf("reading header of %q block at offset %d: %v", c.want.Code, blk.off, err)
+			return
+		}
+		if hdr != c.want {
+			c.err = fmt.Errorf("header %+v of block at offset %d does not match index entry %+v; stale index", hdr, blk.off, c.want)
+		}
+	})
+	return c.err
+}
@@ -0,0 +1,76 @@
+package block
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Limits bounds the resources used to decode blend files, which may be
+// malicious or truncated. A zero field uses the corresponding limit of
+// DefaultLimits, and a negative field disables the limit.
+type Limits struct {
+	// MaxBlockSize is the maximum size in bytes of a block body.
+	MaxBlockSize int64
+	// MaxDNAEntries is the maximum number of names, types and structures of the
+	// DNA, and of fields per structure.
+	MaxDNAEntries int
+	// MaxAlloc is the maximum total size in bytes of the block bodies parsed and
+	// retained by a block reader; bodies of blocks streamed by Reader.Walk are
+	// not counted.
+	MaxAlloc int64
+}
+
+// DefaultLimits specifies the default resource limits of decoding.
+var DefaultLimits = Limits{
+	MaxBlockSize:  1 << 30,
+	MaxDNAEntries: 1 << 16,
+	MaxAlloc:      1 << 32,
+}
+
+// resolve returns the limits with zero fields replaced by their defaults.
+func (l Limits) resolve() Limits {
+	if l.MaxBlockSize == 0 {
+		l.MaxBlockSize = DefaultLimits.MaxBlockSize
+	}
+	if l.MaxDNAEntries == 0 {
+		l.MaxDNAEntries = DefaultLimits.MaxDNAEntries
+	}
+	if l.MaxAlloc == 0 {
+		l.MaxAlloc = DefaultLimits.MaxAlloc
+	}
+	return l
+}
+
+// checkBlockSize checks the size of a block body against the limits.
+func (l Limits) checkBlockSize(size int64) error {
+	l = l.resolve()
+	if size < 0 || (l.MaxBlockSize > 0 && size > l.MaxBlockSize) {
+		return fmt.Errorf("block size %d exceeds limit of %d bytes", size, l.MaxBlockSize)
+	}
+	return nil
+}
+
+// checkDNAEntries checks the number of entries of the given kind of the DNA
+// against the limits.
+func (l Limits) checkDNAEntries(kind string, n int) error {
+	l = l.resolve()
+	if n < 0 || (l.MaxDNAEntries > 0 && n > l.MaxDNAEntries) {
+		return fmt.Errorf("%s count %d exceeds limit of %d", kind, n, l.MaxDNAEntries)
+	}
+	return nil
+}
+
+// allocCounter counts the total size in bytes of parsed block bodies.
+type allocCounter struct {
+	n atomic.Int64
+}
+
+// add adds size bytes to the counter, and checks the total against the limits.
+func (c *allocCounter) add(l Limits, size int64) error {
+	l = l.resolve()
+	total := c.n.Add(size)
+	if l.MaxAlloc > 0 && total > l.MaxAlloc {
+		return fmt.Errorf("total size %d of block bodies exceeds limit of %d bytes", total, l.MaxAlloc)
+	}
+	return nil
+}
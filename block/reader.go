@@ -4,7 +4,6 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
-	"log"
 )
 
 type readSeekerAt interface {
@@ -12,6 +11,14 @@ type readSeekerAt interface {
 	io.ReaderAt
 }
 
+// A slicer provides access to the bytes of its source without copying; e.g. a
+// memory mapped file.
+type slicer interface {
+	// Slice returns the n bytes at offset off, and reports whether zero-copy
+	// access is supported.
+	Slice(off, n int64) ([]byte, bool)
+}
+
 type Reader struct {
 	PtrSize int
 	Order   binary.ByteOrder
@@ -20,20 +27,42 @@ type Reader struct {
 	// Pointers is a map from the memory address of a structure (when it was written to
 	// disk) to its file block.
 	Pointers map[uint64]*Block
+	// Diag collects the diagnostics of reading blocks and parsing their bodies.
+	Diag *Diagnostics
+	// Limits bounds the resources used to read blocks and parse their bodies.
+	Limits Limits
+
+	// alloc counts the total size of the parsed block bodies.
+	alloc allocCounter
+	// global maps from memory address to the non-DATA blocks read so far.
+	global map[uint64]*Block
+	// owned maps from memory address to the blocks of the current owner (i.e.
+	// the last non-DATA block read, and the DATA blocks read since).
+	owned map[uint64]*Block
 }
 
 func NewReader(order binary.ByteOrder, ptrSize int, version int) *Reader {
+	return NewReaderWithDiagnostics(order, ptrSize, version, new(Diagnostics))
+}
+
+// NewReaderWithDiagnostics returns a new block reader, which reports
+// diagnostics to diag.
+func NewReaderWithDiagnostics(order binary.ByteOrder, ptrSize int, version int, diag *Diagnostics) *Reader {
 	r := &Reader{
 		PtrSize:  ptrSize,
 		Order:    order,
 		Pointers: make(map[uint64]*Block),
+		Diag:     diag,
 	}
 
 	s, ok := Versions[version]
 	if !ok {
-		log.Printf("Warning: Version mismatch: %d not supported.\n", version)
-		log.Println("Use blendef [1] to regenerate the block package.")
-		r.Parser = Versions[400]
+		s = Versions[400]
+		diag.report(&Diagnostic{
+			Kind:   DiagVersion,
+			Offset: -1,
+			Msg:    fmt.Sprintf("version %d not supported; using structure definitions of version 400 (use blendef to regenerate the block package)", version),
+		})
 	}
 	r.Parser = s
 
@@ -42,30 +71,122 @@ func NewReader(order binary.ByteOrder, ptrSize int, version int) *Reader {
 
 // ReadBlock parses and returns a file block.
 func (r *Reader) ReadBlock(src readSeekerAt) (blk *Block, err error) {
-	blk = new(Block)
-	blk.r = r
+	blk, err = r.readBlock(src)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.record(blk); err != nil {
+		return nil, fmt.Errorf("Reader.ReadBlock: %v", err)
+	}
+	return blk, nil
+}
 
+// BlockAt returns the file block located by the given index entry, and records
+// it in r.Pointers as ReadBlock does. The header of the block is taken from the
+// index entry rather than read from src; the body is read from src when parsed.
+// The header stored in src is checked against the index entry when the body is
+// first read, and reading the body fails if they differ.
+func (r *Reader) BlockAt(src readSeekerAt, entry IndexEntry) (*Block, error) {
+	if entry.Offset < 0 {
+		return nil, fmt.Errorf("Reader.BlockAt: invalid offset %d of %q block at %#x", entry.Offset, entry.Hdr.Code, entry.Hdr.OldAddr)
+	}
+	if err := r.Limits.checkBlockSize(entry.Hdr.Size); err != nil {
+		return nil, fmt.Errorf("Reader.BlockAt: %q block at %#x: %v", entry.Hdr.Code, entry.Hdr.OldAddr, err)
+	}
+	blk := r.newBlock(src, entry.Hdr, entry.Offset)
+	blk.indexed = &indexCheck{
+		want: entry.Hdr,
+		sr:   io.NewSectionReader(src, entry.Offset, int64(HeaderSize(r.PtrSize))),
+	}
+	if err := r.record(blk); err != nil {
+		return nil, fmt.Errorf("Reader.BlockAt: %v", err)
+	}
+	return blk, nil
+}
+
+// record records the block in r.Pointers, and reports blocks with different
+// headers sharing the same memory address. As in Blender, DATA blocks belong to
+// the preceding non-DATA block, and the addresses of DATA blocks are only
+// unique among the blocks of the same owner.
+func (r *Reader) record(blk *Block) error {
+	if r.global == nil {
+		r.global = make(map[uint64]*Block)
+	}
+	scope := r.global
+	if blk.Hdr.Code == CodeDATA {
+		if r.owned == nil {
+			r.owned = make(map[uint64]*Block)
+		}
+		scope = r.owned
+	}
+	v, ok := scope[blk.Hdr.OldAddr]
+	if ok && blk.Hdr != v.Hdr {
+		err := r.Diag.report(&Diagnostic{
+			Kind:   DiagDuplicateAddr,
+			Offset: blk.off,
+			Code:   blk.Hdr.Code,
+			Msg:    fmt.Sprintf("multiple occurances of struct address %#x (previous block at offset %#x)", blk.Hdr.OldAddr, v.off),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if blk.Hdr.Code != CodeDATA {
+		r.global[blk.Hdr.OldAddr] = blk
+		r.owned = map[uint64]*Block{blk.Hdr.OldAddr: blk}
+	} else {
+		r.owned[blk.Hdr.OldAddr] = blk
+	}
+	r.Pointers[blk.Hdr.OldAddr] = blk
+	return nil
+}
+
+// readBlock parses and returns a file block, and skips past its body, without
+// recording the block in r.Pointers.
+func (r *Reader) readBlock(src readSeekerAt) (*Block, error) {
 	// Parse block header.
-	blk.Hdr, err = r.ParseHeader(src)
+	hdr, err := r.ParseHeader(src)
 	if err != nil {
 		return nil, fmt.Errorf("parsing header: %v", err)
 	}
 
-	v, ok := r.Pointers[blk.Hdr.OldAddr]
-	if ok && blk.Hdr != v.Hdr {
-		log.Println(fmt.Errorf("Reader.ReadBlock: multiple occurances of struct address %#x", blk.Hdr.OldAddr))
-		log.Println(v.Hdr, blk.Hdr)
+	if err := r.Limits.checkBlockSize(hdr.Size); err != nil {
+		return nil, fmt.Errorf("%q block at %#x: %v", hdr.Code, hdr.OldAddr, err)
 	}
-	r.Pointers[blk.Hdr.OldAddr] = blk
 
-	// Store section reader for block body.
-	off, err := src.Seek(blk.Hdr.Size, io.SeekCurrent)
+	// Skip past block body.
+	off, err := src.Seek(hdr.Size, io.SeekCurrent)
 	if err != nil {
 		return nil, err
 	}
-	blk.sr = io.NewSectionReader(src, off-blk.Hdr.Size, blk.Hdr.Size)
+	return r.newBlock(src, hdr, off-hdr.Size-int64(headerSizeWithoutPtr+r.PtrSize)), nil
+}
 
-	return blk, nil
+// newBlock returns the file block with the given header, which is stored at
+// file offset off of src.
+func (r *Reader) newBlock(src readSeekerAt, hdr Header, off int64) *Block {
+	blk := &Block{Hdr: hdr, r: r, off: off}
+
+	// Store section reader for block body.
+	bodyOff := off + int64(headerSizeWithoutPtr+r.PtrSize)
+	blk.sr = io.NewSectionReader(src, bodyOff, hdr.Size)
+	if s, ok := src.(slicer); ok {
+		if data, ok := s.Slice(bodyOff, hdr.Size); ok {
+			blk.data = data
+		}
+	}
+
+	if _, known := parseCode([]byte(hdr.Code)); !known {
+		r.Diag.report(&Diagnostic{
+			Kind:   DiagUnknownCode,
+			Offset: off,
+			Code:   hdr.Code,
+			Msg:    fmt.Sprintf("block code %q not implemented", string(hdr.Code)),
+		})
+	}
+
+	return blk
 }
 
 type Writer struct {
@@ -73,8 +194,13 @@ type Writer struct {
 	Order   binary.ByteOrder
 }
 
+// WriteBlock writes a file block. The header of blocks with a parsed body is
+// updated to match the encoded size of the body.
 func (w *Writer) WriteBlock(dst io.Writer, blk *Block) error {
 	blk.w = w
+	if err := blk.SyncHeader(w.PtrSize); err != nil {
+		return err
+	}
 	// Parse block header.
 	if err := w.WriteHeader(dst, blk.Hdr); err != nil {
 		return err
@@ -86,6 +212,9 @@ func (w *Writer) WriteBlock(dst io.Writer, blk *Block) error {
 
 	// Untouched body
 	if blk.Body == nil {
+		if err := blk.verify(); err != nil {
+			return fmt.Errorf("Writer.WriteBlock: %v", err)
+		}
 		if _, err := blk.sr.Seek(0, io.SeekStart); err != nil {
 			return fmt.Errorf("failed seeking: %v", err)
 		}
@@ -40,10 +40,10 @@ type IDPropertyUIDataInt struct {
 // SDNA index: 3
 type IDPropertyUIDataBool struct {
 	Base IDPropertyUIData
-	Default_array BlockPointer[*Int8_t]
+	Default_array BlockPointer[*int8]
 	Default_array_len int32
 	X_pad [3]uint8
-	Default_value Int8_t
+	Default_value int8
 }
 
 // SDNA index: 4
@@ -946,7 +946,7 @@ type BrushGpencilSettings struct {
 	Fill_direction int16
 	Fill_threshold float32
 	X_pad2 [2]uint8
-	Caps_type Int8_t
+	Caps_type int8
 	X_pad [5]uint8
 	Flag2 int32
 	Fill_simplylvl int32
@@ -1422,7 +1422,7 @@ type Collection struct {
 	Layer int32
 	Dupli_ofs [3]float32
 	Flag uint8
-	Color_tag Int8_t
+	Color_tag int8
 	X_pad0 [2]uint8
 	Lineart_usage uint8
 	Lineart_flags uint8
@@ -3002,7 +3002,7 @@ type ArmatureGpencilModifierData struct {
 	Multi int16
 	X_pad int32
 	Object BlockPointer[*Object]
-	Vert_coords_prev func() float32
+	Vert_coords_prev BlockPointer[*float32]
 	Vgname [64]uint8
 }
 
@@ -3737,7 +3737,7 @@ type ViewLayerEngineData struct {
 	Prev BlockPointer[*ViewLayerEngineData]
 	Engine_type BlockPointer[*DrawEngineType]
 	Storage BlockPointer[*any]
-	Free func()
+	Free FuncPointer
 }
 
 // SDNA index: 226
@@ -4718,7 +4718,7 @@ type MBoolProperty struct {
 
 // SDNA index: 305
 type MInt8Property struct {
-	I Int8_t
+	I int8
 }
 
 // SDNA index: 306
@@ -4757,7 +4757,7 @@ type MPropCol struct {
 type MDisps struct {
 	Totdisp int32
 	Level int32
-	Disps func() float32
+	Disps BlockPointer[*float32]
 	Hidden BlockPointer[*int32]
 }
 
@@ -5135,7 +5135,7 @@ type ArmatureModifierData struct {
 	Multi int16
 	X_pad2 [4]uint8
 	Object BlockPointer[*Object]
-	Vert_coords_prev func() float32
+	Vert_coords_prev BlockPointer[*float32]
 	Defgrp_name [64]uint8
 }
 
@@ -5182,12 +5182,12 @@ type ClothModifierData struct {
 // SDNA index: 345
 type CollisionModifierData struct {
 	Modifier ModifierData
-	X func() float32
-	Xnew func() float32
-	Xold func() float32
-	Current_xnew func() float32
-	Current_x func() float32
-	Current_v func() float32
+	X BlockPointer[*float32]
+	Xnew BlockPointer[*float32]
+	Xold BlockPointer[*float32]
+	Current_xnew BlockPointer[*float32]
+	Current_x BlockPointer[*float32]
+	Current_v BlockPointer[*float32]
 	Tri BlockPointer[*MVertTri]
 	Mvert_num int32
 	Tri_num int32
@@ -5200,8 +5200,8 @@ type CollisionModifierData struct {
 
 // SDNA index: 346
 type SurfaceModifierData_Runtime struct {
-	Vert_positions_prev func() float32
-	Vert_velocities func() float32
+	Vert_positions_prev BlockPointer[*float32]
+	Vert_velocities BlockPointer[*float32]
 	Mesh BlockPointer[*Mesh]
 	Bvhtree BlockPointer[*BVHTreeFromMesh]
 	Cfra_prev int32
@@ -5263,7 +5263,7 @@ type MeshDeformModifierData struct {
 	Bindmat [4][4]float32
 	Bindweights BlockPointer[*float32]
 	Bindcos BlockPointer[*float32]
-	Bindfunc func()
+	Bindfunc FuncPointer
 }
 
 // SDNA index: 352
@@ -5599,7 +5599,7 @@ type LaplacianSmoothModifierData struct {
 
 // SDNA index: 373
 type CorrectiveSmoothDeltaCache struct {
-	Deltas func() float32
+	Deltas BlockPointer[*float32]
 	Deltas_num int32
 	Lambda float32
 	Scale float32
@@ -5613,7 +5613,7 @@ type CorrectiveSmoothDeltaCache struct {
 // SDNA index: 374
 type CorrectiveSmoothModifierData struct {
 	Modifier ModifierData
-	Bind_coords func() float32
+	Bind_coords BlockPointer[*float32]
 	Bind_coords_num int32
 	Lambda float32
 	Scale float32
@@ -6924,31 +6924,31 @@ type NodeGeometryCurveToPoints struct {
 // SDNA index: 519
 type NodeGeometryCurveSample struct {
 	Mode uint8
-	Use_all_curves Int8_t
-	Data_type Int8_t
+	Use_all_curves int8
+	Data_type int8
 	X_pad [1]uint8
 }
 
 // SDNA index: 520
 type NodeGeometryTransferAttribute struct {
-	Data_type Int8_t
-	Domain Int8_t
+	Data_type int8
+	Domain int8
 	Mode uint8
 	X_pad [1]uint8
 }
 
 // SDNA index: 521
 type NodeGeometrySampleIndex struct {
-	Data_type Int8_t
-	Domain Int8_t
-	Clamp Int8_t
+	Data_type int8
+	Domain int8
+	Clamp int8
 	X_pad [1]uint8
 }
 
 // SDNA index: 522
 type NodeGeometryRaycast struct {
 	Mapping uint8
-	Data_type Int8_t
+	Data_type int8
 	Input_type_ray_direction uint8
 	Input_type_ray_length uint8
 }
@@ -6965,19 +6965,19 @@ type NodeGeometryMeshToPoints struct {
 
 // SDNA index: 525
 type NodeGeometryAttributeCapture struct {
-	Data_type Int8_t
-	Domain Int8_t
+	Data_type int8
+	Domain int8
 }
 
 // SDNA index: 526
 type NodeGeometryStoreNamedAttribute struct {
-	Data_type Int8_t
-	Domain Int8_t
+	Data_type int8
+	Domain int8
 }
 
 // SDNA index: 527
 type NodeGeometryInputNamedAttribute struct {
-	Data_type Int8_t
+	Data_type int8
 }
 
 // SDNA index: 528
@@ -6990,30 +6990,30 @@ type NodeGeometryStringToCurves struct {
 
 // SDNA index: 529
 type NodeGeometryDeleteGeometry struct {
-	Domain Int8_t
-	Mode Int8_t
+	Domain int8
+	Mode int8
 }
 
 // SDNA index: 530
 type NodeGeometryDuplicateElements struct {
-	Domain Int8_t
+	Domain int8
 }
 
 // SDNA index: 531
 type NodeGeometrySeparateGeometry struct {
-	Domain Int8_t
+	Domain int8
 }
 
 // SDNA index: 532
 type NodeGeometryImageTexture struct {
-	Interpolation Int8_t
-	Extension Int8_t
+	Interpolation int8
+	Extension int8
 }
 
 // SDNA index: 533
 type NodeGeometryViewer struct {
-	Data_type Int8_t
-	Domain Int8_t
+	Data_type int8
+	Domain int8
 }
 
 // SDNA index: 534
@@ -7028,24 +7028,24 @@ type NodeGeometryDistributePointsInVolume struct {
 
 // SDNA index: 536
 type NodeFunctionCompare struct {
-	Operation Int8_t
-	Data_type Int8_t
-	Mode Int8_t
+	Operation int8
+	Data_type int8
+	Mode int8
 	X_pad [1]uint8
 }
 
 // SDNA index: 537
 type NodeCombSepColor struct {
-	Mode Int8_t
+	Mode int8
 }
 
 // SDNA index: 538
 type NodeShaderMix struct {
-	Data_type Int8_t
-	Factor_mode Int8_t
-	Clamp_factor Int8_t
-	Clamp_result Int8_t
-	Blend_type Int8_t
+	Data_type int8
+	Factor_mode int8
+	Clamp_factor int8
+	Clamp_result int8
+	Blend_type int8
 	X_pad [3]uint8
 }
 
@@ -7295,8 +7295,8 @@ type Object_Runtime struct {
 	X_pad4 BlockPointer[*any]
 	Local_collections_bits int16
 	X_pad2 [3]int16
-	Crazyspace_deform_imats func() float32
-	Crazyspace_deform_cos func() float32
+	Crazyspace_deform_imats BlockPointer[*float32]
+	Crazyspace_deform_cos BlockPointer[*float32]
 	Crazyspace_num_verts int32
 	X_pad3 [3]int32
 }
@@ -7735,7 +7735,7 @@ type ParticleSystem struct {
 	Particles BlockPointer[*ParticleData]
 	Child BlockPointer[*ChildParticle]
 	Edit BlockPointer[*PTCacheEdit]
-	Free_edit func()
+	Free_edit FuncPointer
 	Pathcache BlockPointer[**ParticleCacheKey]
 	Childcache BlockPointer[**ParticleCacheKey]
 	Pathcachebufs ListBase
@@ -7833,7 +7833,7 @@ type PointCache struct {
 	X_pad1 [4]uint8
 	Mem_cache ListBase
 	Edit BlockPointer[*PTCacheEdit]
-	Free_edit func()
+	Free_edit FuncPointer
 }
 
 // SDNA index: 570
@@ -9074,7 +9074,7 @@ type Sequence struct {
 	Anim_endofs int32
 	Blend_mode int32
 	Blend_opacity float32
-	Color_tag Int8_t
+	Color_tag int8
 	Alpha_mode uint8
 	X_pad4 [2]uint8
 	Cache_flag int32
@@ -11720,8 +11720,8 @@ type WmKeyMapItem struct {
 	Propvalue_str [64]uint8
 	Propvalue int16
 	Type int16
-	Val Int8_t
-	Direction Int8_t
+	Val int8
+	Direction int8
 	Shift int16
 	Ctrl int16
 	Alt int16
@@ -11754,8 +11754,8 @@ type WmKeyMap struct {
 	Owner_id [64]uint8
 	Flag int16
 	Kmi_id int16
-	Poll func() Bool
-	Poll_modal_item func() Bool
+	Poll FuncPointer
+	Poll_modal_item FuncPointer
 	Modal_items BlockPointer[*any]
 }
 
@@ -11975,7 +11975,6 @@ type XrActionMap struct {
 	X_pad [6]uint8
 }
 
-type Int8_t struct{}
 type DrawData struct{}
 type IDOverrideLibraryRuntime struct{}
 type UniqueName_Map struct{}
@@ -12086,6 +12085,5 @@ type WmMsgBus struct{}
 type WmEvent_ConsecutiveData struct{}
 type WmEvent struct{}
 type WmIMEData struct{}
-type Bool struct{}
 type WmOperatorType struct{}
 type BToolRef_Runtime struct{}
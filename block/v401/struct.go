@@ -50,10 +50,10 @@ type IDPropertyUIDataInt struct {
 // SDNA index: 4
 type IDPropertyUIDataBool struct {
 	Base IDPropertyUIData
-	Default_array BlockPointer[*Int8_t]
+	Default_array BlockPointer[*int8]
 	Default_array_len int32
 	X_pad [3]uint8
-	Default_value Int8_t
+	Default_value int8
 }
 
 // SDNA index: 5
@@ -741,7 +741,7 @@ type IdAdtTemplate struct {
 
 // SDNA index: 57
 type BoneColor struct {
-	Palette_index Int8_t
+	Palette_index int8
 	X_pad0 [7]uint8
 	Custom ThemeWireColor
 }
@@ -1039,7 +1039,7 @@ type BrushGpencilSettings struct {
 	Fill_direction int16
 	Fill_threshold float32
 	X_pad2 [2]uint8
-	Caps_type Int8_t
+	Caps_type int8
 	X_pad [5]uint8
 	Flag2 int32
 	Fill_simplylvl int32
@@ -1543,7 +1543,7 @@ type Collection struct {
 	Layer int32
 	Dupli_ofs [3]float32
 	Flag uint8
-	Color_tag Int8_t
+	Color_tag int8
 	X_pad0 [2]uint8
 	Lineart_usage uint8
 	Lineart_flags uint8
@@ -3402,7 +3402,7 @@ type ArmatureGpencilModifierData struct {
 	Multi int16
 	X_pad int32
 	Object BlockPointer[*Object]
-	Vert_coords_prev func() float32
+	Vert_coords_prev BlockPointer[*float32]
 	Vgname [64]uint8
 }
 
@@ -3599,7 +3599,7 @@ type GPUDOFSettings struct {
 
 // SDNA index: 219
 type GreasePencilDrawingBase struct {
-	Type Int8_t
+	Type int8
 	X_pad [3]uint8
 	Flag int32
 }
@@ -3621,7 +3621,7 @@ type GreasePencilDrawingReference struct {
 type GreasePencilFrame struct {
 	Drawing_index int32
 	Flag int32
-	Type Int8_t
+	Type int8
 	X_pad [3]uint8
 }
 
@@ -3648,7 +3648,7 @@ type GreasePencilLayerTreeNode struct {
 	Prev BlockPointer[*GreasePencilLayerTreeNode]
 	Parent BlockPointer[*GreasePencilLayerTreeGroup]
 	Name BlockPointer[*uint8]
-	Type Int8_t
+	Type int8
 	Color [3]uint8
 	Flag int32
 }
@@ -3657,7 +3657,7 @@ type GreasePencilLayerTreeNode struct {
 type GreasePencilLayer struct {
 	Base GreasePencilLayerTreeNode
 	Frames_storage GreasePencilLayerFramesMapStorage
-	Blend_mode Int8_t
+	Blend_mode int8
 	X_pad [3]uint8
 	Opacity float32
 	Masks ListBase
@@ -3674,7 +3674,7 @@ type GreasePencilLayerTreeGroup struct {
 // SDNA index: 228
 type GreasePencilOnionSkinningSettings struct {
 	Opacity float32
-	Mode Int8_t
+	Mode int8
 	Filter uint8
 	X_pad [2]uint8
 	Num_frames_before int16
@@ -3976,7 +3976,7 @@ type ViewLayerEngineData struct {
 	Prev BlockPointer[*ViewLayerEngineData]
 	Engine_type BlockPointer[*DrawEngineType]
 	Storage BlockPointer[*any]
-	Free func()
+	Free FuncPointer
 }
 
 // SDNA index: 247
@@ -4201,20 +4201,20 @@ type LightCache struct {
 
 // SDNA index: 259
 type LightProbeBakingData struct {
-	L0 func() float32
-	L1_a func() float32
-	L1_b func() float32
-	L1_c func() float32
+	L0 BlockPointer[*float32]
+	L1_a BlockPointer[*float32]
+	L1_b BlockPointer[*float32]
+	L1_c BlockPointer[*float32]
 	Validity BlockPointer[*float32]
-	Virtual_offset func() float32
+	Virtual_offset BlockPointer[*float32]
 }
 
 // SDNA index: 260
 type LightProbeIrradianceData struct {
-	L0 func() float32
-	L1_a func() float32
-	L1_b func() float32
-	L1_c func() float32
+	L0 BlockPointer[*float32]
+	L1_a BlockPointer[*float32]
+	L1_b BlockPointer[*float32]
+	L1_c BlockPointer[*float32]
 }
 
 // SDNA index: 261
@@ -4979,7 +4979,7 @@ type MBoolProperty struct {
 
 // SDNA index: 328
 type MInt8Property struct {
-	I Int8_t
+	I int8
 }
 
 // SDNA index: 329
@@ -5018,7 +5018,7 @@ type MPropCol struct {
 type MDisps struct {
 	Totdisp int32
 	Level int32
-	Disps func() float32
+	Disps BlockPointer[*float32]
 	Hidden BlockPointer[*int32]
 }
 
@@ -5422,7 +5422,7 @@ type ArmatureModifierData struct {
 	Multi int16
 	X_pad2 [4]uint8
 	Object BlockPointer[*Object]
-	Vert_coords_prev func() float32
+	Vert_coords_prev BlockPointer[*float32]
 	Defgrp_name [64]uint8
 }
 
@@ -5469,13 +5469,13 @@ type ClothModifierData struct {
 // SDNA index: 371
 type CollisionModifierData struct {
 	Modifier ModifierData
-	X func() float32
-	Xnew func() float32
-	Xold func() float32
-	Current_xnew func() float32
-	Current_x func() float32
-	Current_v func() float32
-	Vert_tris func() int32
+	X BlockPointer[*float32]
+	Xnew BlockPointer[*float32]
+	Xold BlockPointer[*float32]
+	Current_xnew BlockPointer[*float32]
+	Current_x BlockPointer[*float32]
+	Current_v BlockPointer[*float32]
+	Vert_tris BlockPointer[*int32]
 	Mvert_num int32
 	Tri_num int32
 	Time_x float32
@@ -5487,8 +5487,8 @@ type CollisionModifierData struct {
 
 // SDNA index: 372
 type SurfaceModifierData_Runtime struct {
-	Vert_positions_prev func() float32
-	Vert_velocities func() float32
+	Vert_positions_prev BlockPointer[*float32]
+	Vert_velocities BlockPointer[*float32]
 	Mesh BlockPointer[*Mesh]
 	Bvhtree BlockPointer[*BVHTreeFromMesh]
 	Cfra_prev int32
@@ -5550,7 +5550,7 @@ type MeshDeformModifierData struct {
 	Bindmat [4][4]float32
 	Bindweights BlockPointer[*float32]
 	Bindcos BlockPointer[*float32]
-	Bindfunc func()
+	Bindfunc FuncPointer
 }
 
 // SDNA index: 378
@@ -5886,7 +5886,7 @@ type LaplacianSmoothModifierData struct {
 
 // SDNA index: 399
 type CorrectiveSmoothDeltaCache struct {
-	Deltas func() float32
+	Deltas BlockPointer[*float32]
 	Deltas_num int32
 	Lambda float32
 	Scale float32
@@ -5900,7 +5900,7 @@ type CorrectiveSmoothDeltaCache struct {
 // SDNA index: 400
 type CorrectiveSmoothModifierData struct {
 	Modifier ModifierData
-	Bind_coords func() float32
+	Bind_coords BlockPointer[*float32]
 	Bind_coords_num int32
 	Lambda float32
 	Scale float32
@@ -6126,7 +6126,7 @@ type NodesModifierData struct {
 	Node_group BlockPointer[*BNodeTree]
 	Settings NodesModifierSettings
 	Simulation_bake_directory BlockPointer[*uint8]
-	Flag Int8_t
+	Flag int8
 	X_pad [3]uint8
 	Bakes_num int32
 	Bakes BlockPointer[*NodesModifierBake]
@@ -7504,31 +7504,31 @@ type NodeGeometryCurveToPoints struct {
 // SDNA index: 573
 type NodeGeometryCurveSample struct {
 	Mode uint8
-	Use_all_curves Int8_t
-	Data_type Int8_t
+	Use_all_curves int8
+	Data_type int8
 	X_pad [1]uint8
 }
 
 // SDNA index: 574
 type NodeGeometryTransferAttribute struct {
-	Data_type Int8_t
-	Domain Int8_t
+	Data_type int8
+	Domain int8
 	Mode uint8
 	X_pad [1]uint8
 }
 
 // SDNA index: 575
 type NodeGeometrySampleIndex struct {
-	Data_type Int8_t
-	Domain Int8_t
-	Clamp Int8_t
+	Data_type int8
+	Domain int8
+	Clamp int8
 	X_pad [1]uint8
 }
 
 // SDNA index: 576
 type NodeGeometryRaycast struct {
 	Mapping uint8
-	Data_type Int8_t
+	Data_type int8
 }
 
 // SDNA index: 577
@@ -7543,19 +7543,19 @@ type NodeGeometryMeshToPoints struct {
 
 // SDNA index: 579
 type NodeGeometryAttributeCapture struct {
-	Data_type Int8_t
-	Domain Int8_t
+	Data_type int8
+	Domain int8
 }
 
 // SDNA index: 580
 type NodeGeometryStoreNamedAttribute struct {
-	Data_type Int8_t
-	Domain Int8_t
+	Data_type int8
+	Domain int8
 }
 
 // SDNA index: 581
 type NodeGeometryInputNamedAttribute struct {
-	Data_type Int8_t
+	Data_type int8
 }
 
 // SDNA index: 582
@@ -7568,30 +7568,30 @@ type NodeGeometryStringToCurves struct {
 
 // SDNA index: 583
 type NodeGeometryDeleteGeometry struct {
-	Domain Int8_t
-	Mode Int8_t
+	Domain int8
+	Mode int8
 }
 
 // SDNA index: 584
 type NodeGeometryDuplicateElements struct {
-	Domain Int8_t
+	Domain int8
 }
 
 // SDNA index: 585
 type NodeGeometrySeparateGeometry struct {
-	Domain Int8_t
+	Domain int8
 }
 
 // SDNA index: 586
 type NodeGeometryImageTexture struct {
-	Interpolation Int8_t
-	Extension Int8_t
+	Interpolation int8
+	Extension int8
 }
 
 // SDNA index: 587
 type NodeGeometryViewer struct {
-	Data_type Int8_t
-	Domain Int8_t
+	Data_type int8
+	Domain int8
 }
 
 // SDNA index: 588
@@ -7664,24 +7664,24 @@ type NodeGeometryDistributePointsInVolume struct {
 
 // SDNA index: 598
 type NodeFunctionCompare struct {
-	Operation Int8_t
-	Data_type Int8_t
-	Mode Int8_t
+	Operation int8
+	Data_type int8
+	Mode int8
 	X_pad [1]uint8
 }
 
 // SDNA index: 599
 type NodeCombSepColor struct {
-	Mode Int8_t
+	Mode int8
 }
 
 // SDNA index: 600
 type NodeShaderMix struct {
-	Data_type Int8_t
-	Factor_mode Int8_t
-	Clamp_factor Int8_t
-	Clamp_result Int8_t
-	Blend_type Int8_t
+	Data_type int8
+	Factor_mode int8
+	Clamp_factor int8
+	Clamp_result int8
+	Blend_type int8
 	X_pad [3]uint8
 }
 
@@ -8372,7 +8372,7 @@ type ParticleSystem struct {
 	Particles BlockPointer[*ParticleData]
 	Child BlockPointer[*ChildParticle]
 	Edit BlockPointer[*PTCacheEdit]
-	Free_edit func()
+	Free_edit FuncPointer
 	Pathcache BlockPointer[**ParticleCacheKey]
 	Childcache BlockPointer[**ParticleCacheKey]
 	Pathcachebufs ListBase
@@ -8470,7 +8470,7 @@ type PointCache struct {
 	X_pad1 [4]uint8
 	Mem_cache ListBase
 	Edit BlockPointer[*PTCacheEdit]
-	Free_edit func()
+	Free_edit FuncPointer
 }
 
 // SDNA index: 635
@@ -9800,7 +9800,7 @@ type Sequence struct {
 	Anim_endofs int32
 	Blend_mode int32
 	Blend_opacity float32
-	Color_tag Int8_t
+	Color_tag int8
 	Alpha_mode uint8
 	X_pad2 [2]uint8
 	Cache_flag int32
@@ -12204,7 +12204,7 @@ type View3DOverlay struct {
 // SDNA index: 851
 type View3D_Runtime struct {
 	Properties_storage BlockPointer[*any]
-	Properties_storage_free func()
+	Properties_storage_free FuncPointer
 	Flag int32
 	X_pad1 [4]uint8
 	Local_stats BlockPointer[*SceneStats]
@@ -12483,8 +12483,8 @@ type WmKeyMapItem struct {
 	Propvalue_str [64]uint8
 	Propvalue int16
 	Type int16
-	Val Int8_t
-	Direction Int8_t
+	Val int8
+	Direction int8
 	Shift int16
 	Ctrl int16
 	Alt int16
@@ -12517,8 +12517,8 @@ type WmKeyMap struct {
 	Owner_id [64]uint8
 	Flag int16
 	Kmi_id int16
-	Poll func() Bool
-	Poll_modal_item func() Bool
+	Poll FuncPointer
+	Poll_modal_item FuncPointer
 	Modal_items BlockPointer[*any]
 }
 
@@ -12739,7 +12739,6 @@ type XrActionMap struct {
 	X_pad [6]uint8
 }
 
-type Int8_t struct{}
 type DrawData struct{}
 type IDOverrideLibraryRuntime struct{}
 type UniqueName_Map struct{}
@@ -12859,6 +12858,5 @@ type WmEvent_ConsecutiveData struct{}
 type WmEvent struct{}
 type WmIMEData struct{}
 type PointerRNA struct{}
-type Bool struct{}
 type WmOperatorType struct{}
 type BToolRef_Runtime struct{}
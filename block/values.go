@@ -0,0 +1,114 @@
+package block
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// Values decodes the body of blk as a slice of values of type T in one pass;
+// e.g. the vertex positions of a mesh as [][3]float32, or its corner indices as
+// []int32. T must be a fixed-size primitive type (e.g. float32 or int32) or an
+// array thereof, and the body is decoded based on the given byte order of the
+// blend file.
+//
+// Unparsed bodies are read from the blend file, regardless of the SDNA type of
+// the block (e.g. the vec3f structures of mesh positions). Raw bodies ([]byte)
+// and unparsed bodies of memory mapped files are decoded without copying if the
+// byte order matches the byte order of the host, in which case the returned
+// slice shares memory with the body. Bodies parsed into structures are not
+// supported.
+//
+// For blocks read from a memory mapped file (see file.OpenMmap), the returned
+// slice may share memory with the read-only mapping; it must not be modified,
+// and is only valid until the mapping is closed. Use MutableBody before calling
+// Values to modify the values in place.
+func Values[T any](blk *Block, order binary.ByteOrder) ([]T, error) {
+	var zero T
+	elemSize := primitiveSize(reflect.TypeOf(zero))
+	if elemSize <= 0 {
+		return nil, fmt.Errorf("block.Values: unsupported element type %T", zero)
+	}
+	var buf []byte
+	// owned records whether buf may be modified in place.
+	owned := false
+	switch body := blk.Body.(type) {
+	case []byte:
+		buf = body
+	case nil:
+		if blk.data != nil {
+			if err := blk.verify(); err != nil {
+				return nil, fmt.Errorf("block.Values: %v", err)
+			}
+			buf = blk.data
+			break
+		}
+		var err error
+		if buf, err = blk.RawBody(); err != nil {
+			return nil, err
+		}
+		owned = true
+	default:
+		return nil, fmt.Errorf("block.Values: body %T of %q block at %#x is not raw", blk.Body, blk.Hdr.Code, blk.Hdr.OldAddr)
+	}
+	size := int(unsafe.Sizeof(zero))
+	if len(buf)%size != 0 {
+		return nil, fmt.Errorf("block.Values: size %d of %q block at %#x is not a multiple of %d byte %T", len(buf), blk.Hdr.Code, blk.Hdr.OldAddr, size, zero)
+	}
+	n := len(buf) / size
+	if n == 0 {
+		return []T{}, nil
+	}
+
+	swap := elemSize > 1 && !isHostOrder(order)
+	if (!swap || owned) && uintptr(unsafe.Pointer(&buf[0]))%unsafe.Alignof(zero) == 0 {
+		// Reuse buffer.
+		if swap {
+			swapBytes(buf, elemSize)
+		}
+		return unsafe.Slice((*T)(unsafe.Pointer(&buf[0])), n), nil
+	}
+	vals := make([]T, n)
+	dst := unsafe.Slice((*byte)(unsafe.Pointer(&vals[0])), len(buf))
+	copy(dst, buf)
+	if swap {
+		swapBytes(dst, elemSize)
+	}
+	return vals, nil
+}
+
+// primitiveSize returns the size in bytes of the primitive elements of t, which
+// must be a fixed-size primitive type or a (nested) array thereof; or -1 for any
+// other type.
+func primitiveSize(t reflect.Type) int {
+	if t == nil {
+		return -1
+	}
+	switch t.Kind() {
+	case reflect.Array:
+		return primitiveSize(t.Elem())
+	case reflect.Int8, reflect.Uint8, reflect.Int16, reflect.Uint16,
+		reflect.Int32, reflect.Uint32, reflect.Int64, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return int(t.Size())
+	}
+	return -1
+}
+
+// isHostOrder reports whether the given byte order is the byte order of the
+// host.
+func isHostOrder(order binary.ByteOrder) bool {
+	buf := []byte{1, 2}
+	return order.Uint16(buf) == binary.NativeEndian.Uint16(buf)
+}
+
+// swapBytes reverses the byte order of each elemSize byte element of buf.
+func swapBytes(buf []byte, elemSize int) {
+	for off := 0; off+elemSize <= len(buf); off += elemSize {
+		elem := buf[off : off+elemSize]
+		for i, j := 0, elemSize-1; i < j; i, j = i+1, j-1 {
+			elem[i], elem[j] = elem[j], elem[i]
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package block
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+// encodeFloats returns the given float32 values encoded in the byte order.
+func encodeFloats(order binary.ByteOrder, vals ...float32) []byte {
+	buf := make([]byte, 4*len(vals))
+	for i, v := range vals {
+		order.PutUint32(buf[4*i:], math.Float32bits(v))
+	}
+	return buf
+}
+
+func TestValues(t *testing.T) {
+	want := [][3]float32{{1, 2, 3}, {-4, 5.5, 0}}
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		raw := encodeFloats(order, 1, 2, 3, -4, 5.5, 0)
+		blocks := map[string]*Block{
+			"raw":      {Hdr: Header{Code: CodeDATA, Size: int64(len(raw))}, Body: bytes.Clone(raw)},
+			"unparsed": {Hdr: Header{Code: CodeDATA, Size: int64(len(raw))}, sr: io.NewSectionReader(bytes.NewReader(raw), 0, int64(len(raw)))},
+			"mapped":   {Hdr: Header{Code: CodeDATA, Size: int64(len(raw))}, data: bytes.Clone(raw)},
+		}
+		for name, blk := range blocks {
+			got, err := Values[[3]float32](blk, order)
+			if err != nil {
+				t.Errorf("%v %s: %v", order, name, err)
+				continue
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%v %s: expected %v, got %v", order, name, want, got)
+			}
+			// The body is left untouched.
+			if buf, ok := blk.Body.([]byte); ok && !bytes.Equal(buf, raw) {
+				t.Errorf("%v %s: body modified", order, name)
+			}
+			if blk.data != nil && !bytes.Equal(blk.data, raw) {
+				t.Errorf("%v %s: mapped body modified", order, name)
+			}
+		}
+	}
+}
+
+func TestValuesZeroCopy(t *testing.T) {
+	order := binary.ByteOrder(binary.LittleEndian)
+	if !isHostOrder(order) {
+		order = binary.BigEndian
+	}
+	buf := make([]byte, 12)
+	order.PutUint32(buf[8:], 42)
+	blk := &Block{Hdr: Header{Code: CodeDATA, Size: 12}, data: buf}
+	got, err := Values[int32](blk, order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, []int32{0, 0, 42}) {
+		t.Fatalf("expected [0 0 42], got %v", got)
+	}
+	if unsafe.Pointer(&got[0]) != unsafe.Pointer(&buf[0]) {
+		t.Error("values of host byte order copied")
+	}
+}
+
+func TestValuesError(t *testing.T) {
+	raw := &Block{Hdr: Header{Code: CodeDATA, Size: 6}, Body: make([]byte, 6)}
+	if _, err := Values[float32](raw, binary.LittleEndian); err == nil {
+		t.Error("expected error for body size not a multiple of element size")
+	}
+	if _, err := Values[struct{ X float32 }](raw, binary.LittleEndian); err == nil {
+		t.Error("expected error for unsupported element type")
+	}
+	parsed := &Block{Hdr: Header{Code: CodeDATA, Size: 4}, Body: &struct{ X float32 }{}}
+	if _, err := Values[float32](parsed, binary.LittleEndian); err == nil {
+		t.Error("expected error for parsed body")
+	}
+	empty := &Block{Hdr: Header{Code: CodeDATA}, Body: []byte{}}
+	if got, err := Values[float32](empty, binary.LittleEndian); err != nil || len(got) != 0 {
+		t.Errorf("expected no values of empty body, got %v (%v)", got, err)
+	}
+}
@@ -0,0 +1,86 @@
+package block
+
+import (
+	"errors"
+	"fmt"
+)
+
+// A Filter selects file blocks by block code or SDNA structure type name. A
+// block is selected if its code is among Codes or its structure type is among
+// Types. The zero Filter selects all blocks.
+type Filter struct {
+	// Codes holds the block codes to select (e.g. CodeOB).
+	Codes []Code
+	// Types holds the SDNA structure type names to select (e.g. "Object").
+	Types []string
+}
+
+// IsZero reports whether the filter selects all blocks.
+func (f Filter) IsZero() bool {
+	return len(f.Codes) == 0 && len(f.Types) == 0
+}
+
+// match reports whether the filter selects the block with the given header.
+func (f Filter) match(hdr Header, dna *DNA) bool {
+	if f.IsZero() {
+		return true
+	}
+	for _, code := range f.Codes {
+		if hdr.Code == code {
+			return true
+		}
+	}
+	if len(f.Types) == 0 || hdr.SDNAIndex == 0 || int(hdr.SDNAIndex) >= len(dna.Structs) {
+		return false
+	}
+	typ := dna.Structs[hdr.SDNAIndex].Type
+	for _, t := range f.Types {
+		if typ == t {
+			return true
+		}
+	}
+	return false
+}
+
+// SkipAll is used as a return value from the callback of Walk to stop the walk
+// early. It is not returned as an error by Walk.
+var SkipAll = errors.New("skip all blocks")
+
+// Walk reads the file blocks of src, from its current offset up to and
+// excluding the ENDB block, and calls fn for each block selected by filter. The
+// DNA is used to select blocks by structure type name, and may be nil if
+// filter.Types is empty.
+//
+// Unlike ReadBlock, Walk does not record the blocks in r.Pointers, and only
+// reads the headers of blocks which are not selected; memory use is thereby
+// independent of the size of the file, as long as fn does not retain the
+// blocks. The body of a selected block is read from src when parsed by fn; as
+// the blocks are not retained, their bodies do not count against the total of
+// Limits.MaxAlloc.
+//
+// The walk stops at the first error returned by fn, which is returned by Walk;
+// unless the error is SkipAll, in which case Walk returns nil.
+func (r *Reader) Walk(src readSeekerAt, dna *DNA, filter Filter, fn func(blk *Block) error) error {
+	if len(filter.Types) > 0 && dna == nil {
+		return errors.New("Reader.Walk: DNA required to select blocks by structure type")
+	}
+	for {
+		blk, err := r.readBlock(src)
+		if err != nil {
+			return fmt.Errorf("Reader.Walk: reading block: %v", err)
+		}
+		if blk.Hdr.Code == CodeENDB {
+			return nil
+		}
+		if !filter.match(blk.Hdr, dna) {
+			continue
+		}
+		blk.walked = true
+		if err := fn(blk); err != nil {
+			if err == SkipAll {
+				return nil
+			}
+			return err
+		}
+	}
+}
@@ -35,6 +35,7 @@ func main() {
 // blendef parses the provided blend file and generates the following Go files:
 //
 //	struct.go // structure definitions
+//	codec.go  // structure decoders and encoders
 //	parse.go  // block parser logic
 func blendef(filePath string) (err error) {
 	f, err := os.Open(filePath)
@@ -65,6 +66,12 @@ func blendef(filePath string) (err error) {
 		return err
 	}
 
+	// Generate codec.go
+	err = genCodec(b, dna)
+	if err != nil {
+		return err
+	}
+
 	// Generate parse.go
 	err = genParse(b, dna)
 	if err != nil {
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+)
+
+// genCodec generates reflection-free decoders and encoders of the Go structure
+// definitions; i.e. the DecodeFrom and EncodeTo methods of each structure, which
+// read and write the fields of the structure in order.
+//
+// The output is stored in "codec.go".
+func genCodec(b *blend.Blend, dna *block.DNA) (err error) {
+	basic, err := basicTypes(dna)
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "// NOTE: this file has been automatically generated by blendef for Blender v%d.\n", b.Hdr.Ver)
+	fmt.Fprintln(buf)
+	fmt.Fprintf(buf, "package v%d\n", b.Hdr.Ver)
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "import (")
+	fmt.Fprintln(buf, `	"encoding/binary"`)
+	fmt.Fprintln(buf, `	"math"`)
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, `	. "github.com/mewspring/blend/block/generic"`)
+	fmt.Fprintln(buf, ")")
+	fmt.Fprintln(buf)
+
+	// Generate decoders and encoders of Go structure definitions.
+	structs := make(map[string]bool)
+	for _, st := range dna.Structs {
+		structs[st.Type] = true
+		name := strings.Title(st.Type)
+		for _, dir := range []string{"decode", "encode"} {
+			c := &codecGen{buf: buf, basic: basic, dir: dir}
+			c.method(name)
+			fmt.Fprintln(buf, "\toff := 0")
+			for _, field := range st.Fields {
+				if err := c.field(field); err != nil {
+					return fmt.Errorf("genCodec: field %q of %q: %v", field.Name, st.Type, err)
+				}
+			}
+			fmt.Fprintln(buf, "\treturn off")
+			fmt.Fprintln(buf, "}")
+			fmt.Fprintln(buf)
+		}
+	}
+
+	// Generate decoders and encoders of empty Go structure definitions.
+	for _, typ := range dna.Types {
+		if _, ok := basic[typ]; ok || structs[typ] {
+			continue
+		}
+		for _, dir := range []string{"decode", "encode"} {
+			c := &codecGen{buf: buf, basic: basic, dir: dir}
+			c.method(strings.Title(typ))
+			fmt.Fprintln(buf, "\treturn 0")
+			fmt.Fprintln(buf, "}")
+			fmt.Fprintln(buf)
+		}
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("genCodec: %v", err)
+	}
+	return os.WriteFile(fmt.Sprintf("v%d/codec.go", b.Hdr.Ver), src, 0644)
+}
+
+// codecGen generates the decoder or encoder of a Go structure definition.
+type codecGen struct {
+	buf *bytes.Buffer
+	// basic maps from basic C types to Go basic type names.
+	basic map[string]string
+	// dir is the direction of the generated method; "decode" or "encode".
+	dir string
+}
+
+// method generates the signature of the decoder or encoder of the named Go
+// structure.
+func (c *codecGen) method(name string) {
+	if c.dir == "decode" {
+		fmt.Fprintf(c.buf, "// DecodeFrom decodes %s from buf, and returns the number of bytes read.\n", name)
+		fmt.Fprintf(c.buf, "func (t *%s) DecodeFrom(buf []byte, order binary.ByteOrder, ptrSize int) int {\n", name)
+	} else {
+		fmt.Fprintf(c.buf, "// EncodeTo encodes %s to buf, and returns the number of bytes written.\n", name)
+		fmt.Fprintf(c.buf, "func (t *%s) EncodeTo(buf []byte, order binary.ByteOrder, ptrSize int) int {\n", name)
+	}
+}
+
+// field generates the decoding or encoding of the given structure field.
+func (c *codecGen) field(field block.DNAField) error {
+	name, isFunc, ptrCount, arraySizes, _, err := parseName(field.Name)
+	if err != nil {
+		return err
+	}
+	name = strings.Title(name)
+	if strings.HasPrefix(name, "_") {
+		name = "X" + name
+	}
+	expr := "t." + name
+
+	// Byte arrays (e.g. names) are copied as a whole.
+	if !isFunc && ptrCount == 0 && len(arraySizes) == 1 && c.basic[field.Type] == "uint8" {
+		if c.dir == "decode" {
+			fmt.Fprintf(c.buf, "\tcopy(%s[:], buf[off:])\n", expr)
+		} else {
+			fmt.Fprintf(c.buf, "\tcopy(buf[off:], %s[:])\n", expr)
+		}
+		fmt.Fprintf(c.buf, "\toff += %d\n", arraySizes[0])
+		return nil
+	}
+
+	// Loop over array elements.
+	indent := "\t"
+	for i := range arraySizes {
+		fmt.Fprintf(c.buf, "%sfor i%d := range %s {\n", indent, i, expr)
+		expr = fmt.Sprintf("%s[i%d]", expr, i)
+		indent += "\t"
+	}
+	if err := c.elem(indent, expr, field.Type, isFunc || ptrCount > 0); err != nil {
+		return err
+	}
+	for range arraySizes {
+		indent = indent[1:]
+		fmt.Fprintf(c.buf, "%s}\n", indent)
+	}
+	return nil
+}
+
+// elem generates the decoding or encoding of the value expr of the given C type.
+func (c *codecGen) elem(indent, expr, typ string, isPtr bool) error {
+	if isPtr {
+		if c.dir == "decode" {
+			fmt.Fprintf(c.buf, "%s%s.Addr = DecodePointer(buf[off:], order, ptrSize)\n", indent, expr)
+		} else {
+			fmt.Fprintf(c.buf, "%sEncodePointer(buf[off:], order, ptrSize, %s.Addr)\n", indent, expr)
+		}
+		fmt.Fprintf(c.buf, "%soff += ptrSize\n", indent)
+		return nil
+	}
+	def, ok := c.basic[typ]
+	if !ok {
+		// Structure type.
+		if c.dir == "decode" {
+			fmt.Fprintf(c.buf, "%soff += %s.DecodeFrom(buf[off:], order, ptrSize)\n", indent, expr)
+		} else {
+			fmt.Fprintf(c.buf, "%soff += %s.EncodeTo(buf[off:], order, ptrSize)\n", indent, expr)
+		}
+		return nil
+	}
+
+	// Basic type.
+	var dec, enc string
+	var size int
+	switch def {
+	case "uint8":
+		dec, enc, size = "buf[off]", "buf[off] = %s", 1
+	case "int8":
+		dec, enc, size = "int8(buf[off])", "buf[off] = byte(%s)", 1
+	case "uint16", "uint32", "uint64":
+		bits := def[len("uint"):]
+		dec = fmt.Sprintf("order.Uint%s(buf[off:])", bits)
+		enc = fmt.Sprintf("order.PutUint%s(buf[off:], %%s)", bits)
+		size = bitSize(bits)
+	case "int16", "int32", "int64":
+		bits := def[len("int"):]
+		dec = fmt.Sprintf("%s(order.Uint%s(buf[off:]))", def, bits)
+		enc = fmt.Sprintf("order.PutUint%s(buf[off:], uint%s(%%s))", bits, bits)
+		size = bitSize(bits)
+	case "float32":
+		dec, enc, size = "math.Float32frombits(order.Uint32(buf[off:]))", "order.PutUint32(buf[off:], math.Float32bits(%s))", 4
+	case "float64":
+		dec, enc, size = "math.Float64frombits(order.Uint64(buf[off:]))", "order.PutUint64(buf[off:], math.Float64bits(%s))", 8
+	default:
+		return fmt.Errorf("basic type %q (%s) not supported by value", typ, def)
+	}
+	if c.dir == "decode" {
+		fmt.Fprintf(c.buf, "%s%s = %s\n", indent, expr, dec)
+	} else {
+		fmt.Fprintf(c.buf, "%s%s\n", indent, fmt.Sprintf(enc, expr))
+	}
+	fmt.Fprintf(c.buf, "%soff += %d\n", indent, size)
+	return nil
+}
+
+// bitSize returns the size in bytes of the given bit size (e.g. "32").
+func bitSize(bits string) int {
+	n, _ := strconv.Atoi(bits)
+	return n / 8
+}
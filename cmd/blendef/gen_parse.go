@@ -69,8 +69,8 @@ import (
 func ParseStructure(r io.Reader, order binary.ByteOrder, ptrSize int, typ string, count uint32) (body any, err error) {
 	switch typ {
     {{- range .Types }}
-	case "{{ . | title }}":
-		body, err = ReadT[{{ . | title }}](r, order, ptrSize, count)
+	case "{{ . }}":
+		body, err = DecodeT[{{ . | title }}](r, order, ptrSize, count)
     {{- end }}
 	}
  
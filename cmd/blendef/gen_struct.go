@@ -18,14 +18,23 @@ var predef = map[string]string{
 	"short":   "int",
 	"int":     "int",
 	"long":    "int",
+	"int8_t":  "int",
+	"int16_t": "int",
+	"int32_t": "int",
 	"int64_t": "int",
 
 	// uint types.
 	"uchar":    "uint",
 	"ushort":   "uint",
 	"ulong":    "uint",
+	"uint8_t":  "uint",
+	"uint16_t": "uint",
+	"uint32_t": "uint",
 	"uint64_t": "uint",
 
+	// bool types.
+	"bool": "uint",
+
 	// float types.
 	"float":  "float",
 	"double": "float",
@@ -40,40 +49,22 @@ var predef = map[string]string{
 //
 // The output is stored in "struct.go".
 func genStruct(b *blend.Blend, dna *block.DNA) (err error) {
-	f, err := os.Create(fmt.Sprintf("v%d/struct.go", b.Hdr.Ver))
+	basic, err := basicTypes(dna)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
 
-	// Map type sizes.
-	size := make(map[string]int)
-	for i, typ := range dna.Types {
-		size[typ] = dna.TypeSizes[i]
+	// Verify that the Go structure definitions match the DNA type sizes, so
+	// that no field is misaligned.
+	if err := checkSizes(dna, basic, b.Hdr.PtrSize); err != nil {
+		return err
 	}
 
-	// Map basic type definitions.
-	basic := make(map[string]string)
-	for _, typ := range dna.Types {
-		def, ok := predef[typ]
-		if ok {
-			n := size[typ]
-			switch n {
-			case 0:
-				basic[typ] = def
-			case 1:
-				basic[typ] = def + "8"
-			case 2:
-				basic[typ] = def + "16"
-			case 4:
-				basic[typ] = def + "32"
-			case 8:
-				basic[typ] = def + "64"
-			default:
-				return fmt.Errorf("genStruct: size %d of basic type %q not supported", n, typ)
-			}
-		}
+	f, err := os.Create(fmt.Sprintf("v%d/struct.go", b.Hdr.Ver))
+	if err != nil {
+		return err
 	}
+	defer f.Close()
 
 	// Generate Go pointer definition.
 	fmt.Fprintf(f, "// NOTE: this file has been automatically generated by blendef for Blender v%d.\n", b.Hdr.Ver)
@@ -96,10 +87,14 @@ func genStruct(b *blend.Blend, dna *block.DNA) (err error) {
 		fmt.Fprintf(f, "type %s struct {\n", strings.Title(st.Type))
 		for _, field := range st.Fields {
 			// Parse and capitalize field name.
-			name, isFunc, ptrCount, arraySizes, err := parseName(field.Name)
+			name, isFunc, ptrCount, arraySizes, targetSizes, err := parseName(field.Name)
 			if err != nil {
 				return err
 			}
+			if isFunc && !field.IsFuncPointer() {
+				// Pointer to array stored as function pointer.
+				isFunc, ptrCount = false, 1
+			}
 			name = strings.Title(name)
 			if strings.HasPrefix(name, "_") {
 				// Somewhat ugly fix for the following binary.Read error:
@@ -118,11 +113,8 @@ func genStruct(b *blend.Blend, dna *block.DNA) (err error) {
 			}
 
 			if isFunc {
-				if field.Type == "void" {
-					fmt.Fprintf(f, "\t%s func()\n", name)
-				} else {
-					fmt.Fprintf(f, "\t%s func() %s\n", name, typ)
-				}
+				// Function pointers are opaque; only their address is stored.
+				fmt.Fprintf(f, "\t%s FuncPointer\n", name)
 			} else {
 				array := new(bytes.Buffer)
 				for _, arraySize := range arraySizes {
@@ -130,6 +122,11 @@ func genStruct(b *blend.Blend, dna *block.DNA) (err error) {
 				}
 				if ptrCount > 0 {
 					ptr := strings.Repeat("*", ptrCount)
+					// Pointers to arrays (e.g. "(*disps)[3]") point to the
+					// whole array.
+					for _, targetSize := range targetSizes {
+						ptr += fmt.Sprintf("[%d]", targetSize)
+					}
 					fmt.Fprintf(f, "\t%s %sBlockPointer[%s%s%s]\n", name, array, array, ptr, typ)
 				} else {
 					fmt.Fprintf(f, "\t%s %s%s\n", name, array, typ)
@@ -162,8 +159,99 @@ func genStruct(b *blend.Blend, dna *block.DNA) (err error) {
 	return nil
 }
 
+// basicTypes maps the basic C types of the DNA (e.g. "short") to Go basic type
+// names of the same size (e.g. "int16").
+func basicTypes(dna *block.DNA) (map[string]string, error) {
+	basic := make(map[string]string)
+	for i, typ := range dna.Types {
+		def, ok := predef[typ]
+		if !ok {
+			continue
+		}
+		switch n := dna.TypeSizes[i]; n {
+		case 0:
+			basic[typ] = def
+		case 1:
+			basic[typ] = def + "8"
+		case 2:
+			basic[typ] = def + "16"
+		case 4:
+			basic[typ] = def + "32"
+		case 8:
+			basic[typ] = def + "64"
+		default:
+			return nil, fmt.Errorf("basicTypes: size %d of basic type %q not supported", n, typ)
+		}
+	}
+	return basic, nil
+}
+
+// checkSizes checks that the size of each Go structure definition generated from
+// the DNA, given the Go basic type definitions and the pointer size of the blend
+// file, equals the size of the structure as recorded in the DNA.
+func checkSizes(dna *block.DNA, basic map[string]string, ptrSize int) error {
+	// Map type sizes and structures.
+	size := make(map[string]int)
+	for i, typ := range dna.Types {
+		size[typ] = dna.TypeSizes[i]
+	}
+	structs := make(map[string]block.DNAStruct)
+	for _, st := range dna.Structs {
+		structs[st.Type] = st
+	}
+
+	// goSizes maps from structure type name to the size of its Go structure
+	// definition.
+	goSizes := make(map[string]int)
+	var goSize func(typ string) (int, error)
+	goSize = func(typ string) (int, error) {
+		if _, ok := basic[typ]; ok {
+			return size[typ], nil
+		}
+		st, ok := structs[typ]
+		if !ok {
+			// Empty Go structure definition.
+			return 0, nil
+		}
+		if n, ok := goSizes[typ]; ok {
+			return n, nil
+		}
+		total := 0
+		for _, field := range st.Fields {
+			_, isFunc, ptrCount, arraySizes, _, err := parseName(field.Name)
+			if err != nil {
+				return 0, err
+			}
+			n := ptrSize
+			if !isFunc && ptrCount == 0 {
+				if n, err = goSize(field.Type); err != nil {
+					return 0, err
+				}
+			}
+			for _, arraySize := range arraySizes {
+				n *= arraySize
+			}
+			total += n
+		}
+		goSizes[typ] = total
+		return total, nil
+	}
+
+	for _, st := range dna.Structs {
+		n, err := goSize(st.Type)
+		if err != nil {
+			return err
+		}
+		if want := size[st.Type]; n != want {
+			return fmt.Errorf("checkSizes: size %d of Go structure %q differs from DNA type size %d", n, strings.Title(st.Type), want)
+		}
+	}
+	return nil
+}
+
 // parseName parses the provided string and extracts name, pointer count and
-// array and function information.
+// array and function information. The target sizes are the array sizes of the
+// target of a pointer to an array.
 //
 // Example input strings:
 //
@@ -172,16 +260,25 @@ func genStruct(b *blend.Blend, dna *block.DNA) (err error) {
 //	"*point_cache[2]"
 //	"clip[6][4]"
 //	"(*free_edit)()"
-func parseName(s string) (name string, isFunc bool, ptrCount int, arraySizes []int, err error) {
-	// Parse function pointer.
+//	"(*disps)[3]"
+func parseName(s string) (name string, isFunc bool, ptrCount int, arraySizes, targetSizes []int, err error) {
 	if len(s) > 1 && s[0] == '(' && s[1] == '*' {
 		p := s[2:]
 		end := strings.Index(p, ")")
 		if end == -1 {
-			return "", false, 0, nil, fmt.Errorf("parseName: unmatched opening parenthesis in %q", s)
+			return "", false, 0, nil, nil, fmt.Errorf("parseName: unmatched opening parenthesis in %q", s)
+		}
+		name, p = p[:end], p[end+1:]
+		// Parse pointer to array.
+		if strings.HasPrefix(p, "[") {
+			targetSizes, err = parseArraySizes(s, p)
+			if err != nil {
+				return "", false, 0, nil, nil, err
+			}
+			return name, false, 1, nil, targetSizes, nil
 		}
-		name = p[:end]
-		return name, true, 0, nil, nil
+		// Parse function pointer.
+		return name, true, 0, nil, nil, nil
 	}
 
 	// Parse pointer count.
@@ -197,31 +294,38 @@ func parseName(s string) (name string, isFunc bool, ptrCount int, arraySizes []i
 	// Parse name.
 	pos := strings.Index(p, "[")
 	if pos == -1 {
-		return p, false, ptrCount, nil, nil
+		return p, false, ptrCount, nil, nil, nil
 	}
 	name = p[:pos]
-	p = p[pos:]
+	arraySizes, err = parseArraySizes(s, p[pos:])
+	if err != nil {
+		return "", false, 0, nil, nil, err
+	}
+	return name, false, ptrCount, arraySizes, nil, nil
+}
 
-	// Parse array sizes.
+// parseArraySizes parses the array sizes of p (e.g. "[6][4]"), which is a
+// suffix of the field name s.
+func parseArraySizes(s, p string) (arraySizes []int, err error) {
 	for {
 		// Get start position.
 		pos := strings.Index(p, "[")
 		if pos == -1 {
-			return name, false, ptrCount, arraySizes, nil
+			return arraySizes, nil
 		}
 		p = p[pos+1:]
 
 		// Get end position.
 		end := strings.Index(p, "]")
 		if end == -1 {
-			return "", false, 0, nil, fmt.Errorf("parseName: unmatched opening bracket in %q", s)
+			return nil, fmt.Errorf("parseName: unmatched opening bracket in %q", s)
 		}
 		num := p[:end]
 		p = p[end+1:]
 
 		arraySize, err := strconv.Atoi(num)
 		if err != nil {
-			return "", false, 0, nil, err
+			return nil, err
 		}
 		arraySizes = append(arraySizes, arraySize)
 	}
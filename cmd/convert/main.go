@@ -0,0 +1,118 @@
+// convert is a tool which converts blend files between byte orders and pointer
+// sizes; e.g. from big-endian 32-bit files to little-endian 64-bit files. It
+// optionally re-encodes blend files deterministically.
+package main
+
+import (
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/file"
+)
+
+var (
+	// output is the path of the output blend file.
+	output string
+	// order is the byte order of the output blend file.
+	order string
+	// ptrSize is the pointer size of the output blend file.
+	ptrSize int
+	// deterministic renumbers memory addresses and zeroes runtime-only fields.
+	deterministic bool
+)
+
+func init() {
+	flag.Usage = usage
+	flag.StringVar(&output, "o", "", "output path (default FILE_conv.blend)")
+	flag.StringVar(&order, "order", "", "byte order of output (little or big; default same as input)")
+	flag.IntVar(&ptrSize, "ptr", 0, "pointer size of output in bytes (4 or 8; default same as input)")
+	flag.BoolVar(&deterministic, "det", false, "renumber memory addresses and zero runtime-only fields for reproducible output")
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: convert [OPTION]... FILE.blend")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Flags:")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Printf("invalid argument count.")
+		flag.Usage()
+		os.Exit(1)
+	}
+	var byteOrder binary.ByteOrder
+	switch order {
+	case "":
+	case "little":
+		byteOrder = binary.LittleEndian
+	case "big":
+		byteOrder = binary.BigEndian
+	default:
+		log.Printf("invalid byte order %q.", order)
+		flag.Usage()
+		os.Exit(1)
+	}
+	if ptrSize != 0 && ptrSize != 4 && ptrSize != 8 {
+		log.Printf("invalid pointer size %d.", ptrSize)
+		flag.Usage()
+		os.Exit(1)
+	}
+	blendPath := flag.Arg(0)
+	if output == "" {
+		output = strings.TrimSuffix(blendPath, ".blend") + "_conv.blend"
+	}
+
+	if err := convert(blendPath, output, byteOrder, ptrSize); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// convert converts the blend file at blendPath to the given byte order and
+// pointer size (if non-zero), and writes the converted blend file to output.
+func convert(blendPath, output string, order binary.ByteOrder, ptrSize int) error {
+	f, err := os.Open(blendPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	decoder, err := file.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer decoder.Close()
+
+	b, err := blend.Decode(decoder)
+	if err != nil {
+		return err
+	}
+
+	var unswapped []*block.Block
+	opts := blend.EncodeOptions{
+		Deterministic: deterministic,
+		Order:         order,
+		PtrSize:       ptrSize,
+		Unswapped:     &unswapped,
+	}
+	if err := blend.EncodeFile(output, b, opts); err != nil {
+		return err
+	}
+	if len(unswapped) > 0 {
+		var size int64
+		for _, blk := range unswapped {
+			size += blk.Hdr.Size
+		}
+		log.Printf("%d raw blocks (%d bytes) of unknown layout copied without byte swapping", len(unswapped), size)
+	}
+	return nil
+}
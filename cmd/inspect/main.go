@@ -1,24 +1,39 @@
 package main
 
 import (
+	"bufio"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"io/fs"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/mewspring/blend"
 	"github.com/mewspring/blend/block"
 	v400 "github.com/mewspring/blend/block/v400"
 	"github.com/mewspring/blend/file"
+	"github.com/mewspring/blend/packed"
+)
+
+var (
+	// indexPath is the path of the block index sidecar file.
+	indexPath string
 )
 
 func init() {
 	flag.Usage = usage
+	flag.StringVar(&indexPath, "index", "", "block index sidecar file; built if missing or stale (default none)")
 }
 
 func usage() {
-	fmt.Fprintln(os.Stderr, "Usage: inspect FILE.blend")
+	fmt.Fprintln(os.Stderr, "Usage: inspect [OPTION]... FILE.blend")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Flags:")
+	flag.PrintDefaults()
 }
 
 func main() {
@@ -31,7 +46,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	f, err := os.Open(os.Args[1])
+	f, err := os.Open(flag.Arg(0))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -43,7 +58,7 @@ func main() {
 	}
 	defer decoder.Close()
 
-	b, err := blend.Decode(decoder)
+	b, err := decode(decoder, indexPath)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -80,28 +95,83 @@ func main() {
 				log.Println(path)
 			}
 
-			//log.Printf("%+v", body.Source)
-			if body.Packedfile.Addr != 0 {
-				data := b.OldAddr[body.Packedfile.Addr]
-				if err := data.ParseBody(dna); err != nil {
-					log.Fatal(err)
-				}
-
-				pf := data.Body.(*v400.PackedFile)
-
-				pfData := b.OldAddr[pf.Data.Addr]
-				if err := pfData.ParseBody(dna); err != nil {
-					log.Fatal(err)
-				}
-
-				//log.Printf("pfData: %T; Size: %d; Seek: %d", pfData.Body, pf.Size, pf.Seek)
-			}
-
 			//log.Println(path, body.Packedfile)
 		default:
 			log.Printf("unhandled: %T", body)
 		}
 	}
+
+	files, err := packed.Files(b, dna)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, pf := range files {
+		log.Printf("packed: %s %q (%d bytes)", pf.ID(), pf.Path, pf.Size())
+	}
+}
+
+// decode decodes the blend file read by d. If indexPath is non-empty, the
+// blend file is decoded using the block index stored at indexPath, which is
+// (re)built if missing or stale.
+func decode(d *file.Reader, indexPath string) (*blend.Blend, error) {
+	if indexPath == "" {
+		return blend.Decode(d)
+	}
+	idx, err := readIndex(indexPath)
+	if err == nil {
+		b, err := blend.DecodeIndexed(d, idx, blend.DecodeOptions{})
+		if err == nil {
+			return b, nil
+		}
+		log.Printf("rebuilding index: %v", err)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		log.Printf("rebuilding index: %v", err)
+	}
+
+	if _, err := d.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if idx, err = blend.BuildIndex(d); err != nil {
+		return nil, err
+	}
+	if err := writeIndex(indexPath, idx); err != nil {
+		return nil, err
+	}
+	return blend.DecodeIndexed(d, idx, blend.DecodeOptions{})
+}
+
+// readIndex reads the block index stored at the given path.
+func readIndex(path string) (*blend.Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return blend.ReadIndex(bufio.NewReader(f))
+}
+
+// writeIndex writes the block index to the given path. The index is first
+// written to a temporary file in the same directory, so that a concurrent
+// reader never observes a partially written index.
+func writeIndex(path string, idx *blend.Index) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".inspect-*.idx")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := idx.WriteTo(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
 }
 
 func int8SliceToString(s []uint8) string {
@@ -0,0 +1,88 @@
+// libdeps is a tool which resolves the linked libraries of a blend file
+// recursively, and outputs the dependency graph as JSON or DOT.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/mewspring/blend/library"
+)
+
+var (
+	// format is the output format of the dependency graph.
+	format string
+	// strict exits with a non-zero status if the graph has missing files,
+	// missing IDs or cycles.
+	strict bool
+)
+
+func init() {
+	flag.Usage = usage
+	flag.StringVar(&format, "format", "json", "output format (json or dot)")
+	flag.BoolVar(&strict, "strict", false, "exit with status 2 on missing files, missing IDs or cycles")
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: libdeps [OPTION]... FILE.blend")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Flags:")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Printf("invalid argument count.")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	g, err := library.Resolve(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	switch format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "\t")
+		err = enc.Encode(g)
+	case "dot":
+		err = g.WriteDOT(os.Stdout)
+	default:
+		log.Fatalf("unknown output format %q", format)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if strict && !complete(g) {
+		os.Exit(2)
+	}
+}
+
+// complete reports whether all library files and linked IDs of the graph are
+// present, and the graph is free of cycles.
+func complete(g *library.Graph) bool {
+	ok := len(g.Cycles) == 0
+	for _, f := range g.Files {
+		if f.Missing || f.Error != "" {
+			log.Printf("unresolved library %q", f.Path)
+			ok = false
+		}
+		for _, lib := range f.Libraries {
+			for _, id := range lib.MissingIDs {
+				log.Printf("missing %q in library %q of %q", id, lib.Path, f.Path)
+				ok = false
+			}
+		}
+	}
+	for _, cycle := range g.Cycles {
+		log.Printf("library cycle %q", cycle)
+	}
+	return ok
+}
@@ -1,27 +1,45 @@
+// pack is a tool which packs the external resources (images, fonts and sounds)
+// referenced by a blend file into the blend file.
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
-	"io"
+	"io/fs"
 	"log"
 	"os"
 	"strings"
-	"unsafe"
 
 	"github.com/mewspring/blend"
 	"github.com/mewspring/blend/block"
 	"github.com/mewspring/blend/block/generic"
-	v400 "github.com/mewspring/blend/block/v400"
 	"github.com/mewspring/blend/file"
+	"github.com/mewspring/blend/packed"
+)
+
+var (
+	// output is the path of the output blend file.
+	output string
+	// dryRun reports what would be packed without writing any file.
+	dryRun bool
+	// format is the output format of the report.
+	format string
 )
 
 func init() {
 	flag.Usage = usage
+	flag.StringVar(&output, "o", "", "output path (default FILE_packed.blend)")
+	flag.BoolVar(&dryRun, "n", false, "dry run; report what would be packed without writing")
+	flag.StringVar(&format, "format", "text", "output format of the report (text or json)")
 }
 
 func usage() {
-	fmt.Fprintln(os.Stderr, "Usage: inspect FILE.blend")
+	fmt.Fprintln(os.Stderr, "Usage: pack [OPTION]... FILE.blend")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Flags:")
+	flag.PrintDefaults()
 }
 
 func main() {
@@ -32,164 +50,160 @@ func main() {
 		flag.Usage()
 		os.Exit(1)
 	}
+	blendPath := flag.Arg(0)
+	if output == "" {
+		output = strings.TrimSuffix(blendPath, ".blend") + "_packed.blend"
+	}
 
-	f, err := os.Open(os.Args[1])
+	rep, err := pack(blendPath, output, dryRun)
+	if err != nil {
+		log.Fatal(err)
+	}
+	switch format {
+	case "text":
+		rep.print()
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "\t")
+		err = enc.Encode(rep)
+	default:
+		log.Fatalf("unknown output format %q", format)
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
+}
+
+// A report lists the external resources of a blend file by outcome of packing.
+type report struct {
+	// Packed holds the resources packed into the blend file.
+	Packed []*resource `json:"packed"`
+	// Missing holds the resources whose files do not exist.
+	Missing []*resource `json:"missing"`
+	// Linked holds the resources of IDs linked from libraries, which are
+	// packed into their library files rather than into the blend file.
+	Linked []*resource `json:"linked"`
+}
+
+// A resource is an external file referenced by an ID datablock.
+type resource struct {
+	// ID is the name of the ID, including its two-letter code prefix.
+	ID string `json:"id"`
+	// Path is the file path as stored in the ID (e.g. //textures/wood.png).
+	Path string `json:"path"`
+	// AbsPath is the absolute file system path of the file, resolved relative
+	// to the blend file or the library file of linked IDs.
+	AbsPath string `json:"abs_path"`
+	// Size is the size in bytes of the file; or zero if missing.
+	Size int `json:"size,omitempty"`
+}
+
+// print prints the report to standard output.
+func (rep *report) print() {
+	for _, res := range rep.Packed {
+		fmt.Printf("packed  %s: %s (%d bytes)\n", res.ID, res.AbsPath, res.Size)
+	}
+	for _, res := range rep.Linked {
+		fmt.Printf("linked  %s: %s\n", res.ID, res.AbsPath)
+	}
+	for _, res := range rep.Missing {
+		fmt.Printf("missing %s: %s\n", res.ID, res.AbsPath)
+	}
+	fmt.Printf("%d packed, %d linked, %d missing\n", len(rep.Packed), len(rep.Linked), len(rep.Missing))
+}
+
+// pack packs the external resources of the blend file at blendPath and writes
+// the result to output, unless dryRun is set.
+func pack(blendPath, output string, dryRun bool) (*report, error) {
+	f, err := os.Open(blendPath)
+	if err != nil {
+		return nil, err
+	}
 	defer f.Close()
 
 	decoder, err := file.NewReader(f)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	defer decoder.Close()
 
 	b, err := blend.Decode(decoder)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	log.Printf("%+v", b.Hdr)
-
-	newBlocks := make([]*block.Block, len(b.Blocks))
-	copy(newBlocks, b.Blocks)
 
 	dna, err := b.GetDNA()
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
-	for i, blk := range b.Blocks {
+	rep := &report{
+		Packed:  []*resource{},
+		Missing: []*resource{},
+		Linked:  []*resource{},
+	}
+	// Pack inserts blocks into b.Blocks; iterate over the original blocks.
+	blks := append([]*block.Block(nil), b.Blocks...)
+	for _, blk := range blks {
 		switch blk.Hdr.Code {
-		case block.CodeSC, block.CodeIM, block.CodeMA:
-			break
+		case block.CodeIM, block.CodeVF, block.CodeSO:
 		default:
 			continue
 		}
-
 		if err := blk.ParseBody(dna); err != nil {
-			log.Fatal(err)
+			return nil, err
+		}
+		path := generic.FieldString(blk.Body, packed.PathFields[blk.Hdr.Code])
+		if !packable(blk, path) {
+			continue
+		}
+		// Paths of linked IDs are resolved relative to their library file.
+		absPath, err := packed.AbsPath(b, dna, blendPath, blk, path)
+		if err != nil {
+			return nil, err
+		}
+		res := &resource{ID: generic.IDName(blk.Body), Path: path, AbsPath: absPath}
+		data, err := os.ReadFile(absPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			rep.Missing = append(rep.Missing, res)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		res.Size = len(data)
+		if packed.LibAddr(blk.Body) != 0 {
+			// As in Blender, linked IDs are not packed into the blend file.
+			rep.Linked = append(rep.Linked, res)
+			continue
 		}
 
-		switch body := blk.Body.(type) {
-		case *v400.Image:
-			path := int8SliceToString(body.Name[:])
-			if len(path) == 0 {
-				log.Printf("empty name: %+v", int8SliceToString(body.Id.Name[:]))
-				continue
-			}
-
-			if body.Packedfile.Addr != 0 {
-				data := b.OldAddr[body.Packedfile.Addr]
-				if err := data.ParseBody(dna); err != nil {
-					log.Fatal(err)
-				}
-
-				log.Printf("pfHeader: %+v", data.Hdr)
-
-				pf := data.Body.(*v400.PackedFile)
-				pfData := b.OldAddr[pf.Data.Addr]
-
-				if err := pfData.ParseBody(dna); err != nil {
-					log.Fatal(err)
-				}
-
-				log.Printf("pfDataHeader: %+v; ", pfData.Hdr)
-				log.Printf("pfData: %T; Size: %d; Seek: %d", pfData.Body, pf.Size, pf.Seek)
-			} else {
-				log.Printf("MISSING!")
-
-				ab, bb := packFile(path, dna, b, body)
-				newBlocks = append(newBlocks[:i], append([]*block.Block{ab, bb}, newBlocks[i:]...)...)
+		if !dryRun {
+			if _, err := packed.Pack(b, dna, blk, path, data); err != nil {
+				return nil, err
 			}
-
-			log.Println(path, body.Packedfile)
-		default:
-			log.Printf("unhandled: %T", body)
 		}
+		rep.Packed = append(rep.Packed, res)
 	}
 
-	b.Blocks = newBlocks
-	for _, blk := range b.Blocks {
-		log.Printf("%+v", blk.Hdr)
-	}
-
-	nf, err := os.OpenFile(os.Args[1]+"_new.blend", os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer nf.Close()
-
-	if err := blend.Encode(nf, b); err != nil {
-		log.Fatal(err)
+	if dryRun || len(rep.Packed) == 0 {
+		return rep, nil
 	}
+	return rep, blend.EncodeFile(output, b, blend.EncodeOptions{})
 }
 
-func packFile(path string, dna *block.DNA, b *blend.Blend, body *v400.Image) (*block.Block, *block.Block) {
-	open, err := os.Open("/Users/fionera/Downloads/_" + strings.ReplaceAll(path, "//", ""))
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	all, err := io.ReadAll(open)
-	if err != nil {
-		log.Fatal(err)
+// packable reports whether the ID block blk with the given file path refers to
+// an external file which is not yet packed.
+func packable(blk *block.Block, path string) bool {
+	if path == "" || packed.IsPacked(blk.Body) {
+		return false
 	}
-
-	var idx int
-	for i, s := range dna.Structs {
-		if s.Type == "PackedFile" {
-			idx = i
-			break
-		}
-	}
-
-	dataBlock := &block.Block{
-		Hdr: block.Header{
-			Code:      block.CodeDATA,
-			Size:      int64(len(all)),
-			OldAddr:   0,
-			SDNAIndex: 0, // is set to 0
-			Count:     1,
-		},
-		Body: all,
-	}
-	dataBlock.Hdr.OldAddr = uint64(uintptr(unsafe.Pointer(&dataBlock))) * 2
-	log.Printf("dataBlock: %+v", dataBlock.Hdr)
-
-	pfBlock := &block.Block{
-		Hdr: block.Header{
-			Code:      block.CodeDATA,
-			Size:      int64(8 + b.Hdr.PtrSize),
-			OldAddr:   0,
-			SDNAIndex: uint32(idx),
-			Count:     1,
-		},
-		Body: v400.PackedFile{
-			Size: int32(dataBlock.Hdr.Size),
-			Seek: 0,
-			Data: generic.BlockPointer[*any](generic.BlockPointer[[]uint8]{
-				Addr: dataBlock.Hdr.OldAddr,
-			}),
-		},
-	}
-	pfBlock.Hdr.OldAddr = uint64(uintptr(unsafe.Pointer(&pfBlock))) * 2
-	log.Printf("pfHeader: %+v", pfBlock.Hdr)
-
-	body.Packedfile.Addr = pfBlock.Hdr.OldAddr
-	body.Packedfiles.First.Addr = pfBlock.Hdr.OldAddr
-	body.Packedfiles.Last.Addr = pfBlock.Hdr.OldAddr
-
-	return pfBlock, dataBlock
-}
-
-func int8SliceToString(s []uint8) string {
-	var sb strings.Builder
-	for _, v := range s {
-		if v == 0 {
-			break
-		}
-		sb.WriteByte(v)
+	switch blk.Hdr.Code {
+	case block.CodeIM:
+		source := generic.Field(blk.Body, "Source")
+		return source.IsValid() && source.Int() == packed.ImageSourceFile
+	case block.CodeVF:
+		// Built-in font.
+		return path != "<builtin>"
 	}
-	return sb.String()
+	return true
 }
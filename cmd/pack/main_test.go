@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+	"github.com/mewspring/blend/file"
+	"github.com/mewspring/blend/packed"
+)
+
+// decodeFile decodes the blend file at the given path, and returns it with its
+// DNA.
+func decodeFile(t *testing.T, path string) (*blend.Blend, *block.DNA) {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Skip(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	d, err := file.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := blend.Decode(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dna, err := b.GetDNA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, dna
+}
+
+// writeImageBlend writes a copy of the v400 golden file to dir, with the image
+// of the golden file turned into an external image file at the given path.
+func writeImageBlend(t *testing.T, dir, path string) string {
+	t.Helper()
+	b, dna := decodeFile(t, "../../golden/v400_uncompressed.blend")
+	var img *block.Block
+	for _, blk := range b.Blocks {
+		if blk.Hdr.Code == block.CodeIM {
+			img = blk
+			break
+		}
+	}
+	if img == nil {
+		t.Fatal("unable to locate image of golden file")
+	}
+	if err := img.ParseBody(dna); err != nil {
+		t.Fatal(err)
+	}
+	generic.Field(img.Body, "Source").SetInt(packed.ImageSourceFile)
+	if err := generic.SetFieldString(img.Body, packed.PathFields[block.CodeIM], path); err != nil {
+		t.Fatal(err)
+	}
+	blendPath := filepath.Join(dir, "image.blend")
+	if err := blend.EncodeFile(blendPath, b, blend.EncodeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	return blendPath
+}
+
+func TestPack(t *testing.T) {
+	dir := t.TempDir()
+	blendPath := writeImageBlend(t, dir, "//textures/wood.png")
+	data := []byte("\x89PNG\r\n\x1a\nwood")
+	if err := os.Mkdir(filepath.Join(dir, "textures"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "textures", "wood.png"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	output := filepath.Join(dir, "packed.blend")
+	rep, err := pack(blendPath, output, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rep.Packed) != 1 || len(rep.Missing) != 0 || len(rep.Linked) != 0 {
+		t.Fatalf("expected 1 packed resource, got %d packed, %d missing, %d linked", len(rep.Packed), len(rep.Missing), len(rep.Linked))
+	}
+	res := rep.Packed[0]
+	if want := filepath.Join(dir, "textures", "wood.png"); res.AbsPath != want || res.Size != len(data) {
+		t.Errorf("packed resource mismatch; expected %s (%d bytes), got %s (%d bytes)", want, len(data), res.AbsPath, res.Size)
+	}
+
+	// The packed file is stored in the output blend file.
+	b, dna := decodeFile(t, output)
+	files, err := packed.Files(b, dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected 1 packed file, got %d", len(files))
+	}
+	got, err := files[0].Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("packed file contents mismatch; expected %q, got %q", data, got)
+	}
+
+	// Packed resources are not packed again.
+	rep, err = pack(output, filepath.Join(dir, "repacked.blend"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rep.Packed) != 0 {
+		t.Errorf("expected no packed resources, got %d", len(rep.Packed))
+	}
+}
+
+func TestPackMissing(t *testing.T) {
+	dir := t.TempDir()
+	blendPath := writeImageBlend(t, dir, "//missing.png")
+	output := filepath.Join(dir, "packed.blend")
+	rep, err := pack(blendPath, output, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rep.Packed) != 0 || len(rep.Missing) != 1 {
+		t.Fatalf("expected 1 missing resource, got %d packed, %d missing", len(rep.Packed), len(rep.Missing))
+	}
+	if _, err := os.Stat(output); err == nil {
+		t.Errorf("output %s written without packed resources", output)
+	}
+}
@@ -0,0 +1,169 @@
+// paths is a tool which reports the external file paths referenced by a blend
+// file, and optionally rewrites them.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/file"
+	"github.com/mewspring/blend/paths"
+)
+
+var (
+	// output is the path of the output blend file.
+	output string
+	// dryRun reports what would be rewritten without writing any file.
+	dryRun bool
+	// mappings holds the path prefix mappings to apply.
+	mappings mappingList
+	// relative makes absolute paths relative to the output file.
+	relative bool
+	// absolute makes relative paths absolute.
+	absolute bool
+)
+
+func init() {
+	flag.Usage = usage
+	flag.StringVar(&output, "o", "", "output path (default FILE_paths.blend)")
+	flag.BoolVar(&dryRun, "n", false, "dry run; report what would be rewritten without writing")
+	flag.Var(&mappings, "map", "replace path prefix OLD by NEW (OLD=NEW); may be repeated")
+	flag.BoolVar(&relative, "rel", false, "make absolute paths relative to the output file")
+	flag.BoolVar(&absolute, "abs", false, "make relative paths absolute")
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: paths [OPTION]... FILE.blend")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Without -map, -rel or -abs, the external file paths of FILE are listed.")
+	fmt.Fprintln(os.Stderr, "Prefix mappings are applied first, followed by -abs and -rel.")
+	fmt.Fprintln(os.Stderr, "Relative paths are rebased if the output file is in another directory.")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Flags:")
+	flag.PrintDefaults()
+}
+
+// mappingList is a repeatable flag of path prefix mappings.
+type mappingList []paths.Mapping
+
+func (l *mappingList) String() string {
+	var ss []string
+	for _, m := range *l {
+		ss = append(ss, m.Old+"="+m.New)
+	}
+	return strings.Join(ss, ",")
+}
+
+func (l *mappingList) Set(s string) error {
+	m, err := paths.ParseMapping(s)
+	if err != nil {
+		return err
+	}
+	*l = append(*l, m)
+	return nil
+}
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Printf("invalid argument count.")
+		flag.Usage()
+		os.Exit(1)
+	}
+	if relative && absolute {
+		log.Printf("-rel and -abs are mutually exclusive.")
+		flag.Usage()
+		os.Exit(1)
+	}
+	blendPath := flag.Arg(0)
+	if output == "" {
+		output = strings.TrimSuffix(blendPath, ".blend") + "_paths.blend"
+	}
+
+	if err := rewrite(blendPath, output, dryRun); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// rewrite lists or rewrites the external file paths of the blend file at
+// blendPath, and writes the rewritten blend file to output.
+func rewrite(blendPath, output string, dryRun bool) error {
+	f, err := os.Open(blendPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	decoder, err := file.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer decoder.Close()
+
+	b, err := blend.Decode(decoder)
+	if err != nil {
+		return err
+	}
+
+	dna, err := b.GetDNA()
+	if err != nil {
+		return err
+	}
+
+	if len(mappings) == 0 && !relative && !absolute {
+		ps, err := paths.List(b, dna)
+		if err != nil {
+			return err
+		}
+		for _, p := range ps {
+			linked := ""
+			if p.Linked() {
+				linked = " (linked)"
+			}
+			fmt.Printf("%s %s.%s: %s%s\n", p.Name, p.Type, p.Field, p.Path, linked)
+		}
+		return nil
+	}
+
+	var fns []func(string) string
+	if len(mappings) > 0 {
+		fns = append(fns, paths.MapPrefixes(mappings))
+	}
+	if absolute {
+		fns = append(fns, paths.Absolute(blendPath))
+	}
+	switch {
+	case relative:
+		// Make relative paths absolute first, as they are relative to the
+		// input file rather than the output file.
+		fns = append(fns, paths.Absolute(blendPath), paths.Relative(output))
+	case !absolute && filepath.Dir(output) != filepath.Dir(blendPath):
+		// Relative paths are relative to the input file; keep them pointing
+		// to the same files from the output file.
+		fns = append(fns, paths.Rebase(blendPath, output))
+	}
+	changes, err := paths.Rewrite(b, dna, func(path string) string {
+		for _, fn := range fns {
+			path = fn(path)
+		}
+		return path
+	})
+	for _, c := range changes {
+		fmt.Printf("%s %s.%s: %s -> %s\n", c.Name, c.Type, c.Field, c.Old, c.Path.Path)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d rewritten\n", len(changes))
+
+	if dryRun || len(changes) == 0 {
+		return nil
+	}
+	return blend.EncodeFile(output, b, blend.EncodeOptions{})
+}
@@ -0,0 +1,108 @@
+// rename is a tool which renames an ID datablock of a blend file (e.g. an
+// object or material), keeping the names of IDs of the same type unique.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/file"
+)
+
+var (
+	// output is the path of the output blend file.
+	output string
+	// dryRun reports the new name without writing any file.
+	dryRun bool
+)
+
+func init() {
+	flag.Usage = usage
+	flag.StringVar(&output, "o", "", "output path (default FILE_rename.blend)")
+	flag.BoolVar(&dryRun, "n", false, "dry run; report the new name without writing")
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: rename [OPTION]... FILE.blend OLD NEW")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Renames the local ID named OLD, including its two-letter code prefix (e.g.")
+	fmt.Fprintln(os.Stderr, "OBCube), to NEW, excluding the prefix (e.g. Box). NEW is made unique among")
+	fmt.Fprintln(os.Stderr, "the IDs of the same type by a numeric suffix (e.g. Box.001).")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Flags:")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	flag.Parse()
+	if flag.NArg() != 3 {
+		log.Printf("invalid argument count.")
+		flag.Usage()
+		os.Exit(1)
+	}
+	blendPath := flag.Arg(0)
+	if output == "" {
+		output = strings.TrimSuffix(blendPath, ".blend") + "_rename.blend"
+	}
+
+	if err := rename(blendPath, output, flag.Arg(1), flag.Arg(2), dryRun); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// rename renames the local ID oldName of the blend file at blendPath to
+// newName, and writes the resulting blend file to output.
+func rename(blendPath, output, oldName, newName string, dryRun bool) error {
+	f, err := os.Open(blendPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	decoder, err := file.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer decoder.Close()
+
+	b, err := blend.Decode(decoder)
+	if err != nil {
+		return err
+	}
+
+	dna, err := b.GetDNA()
+	if err != nil {
+		return err
+	}
+
+	m, err := b.Main(dna)
+	if err != nil {
+		return err
+	}
+	id := m.Lookup(oldName, 0)
+	if id == nil {
+		return fmt.Errorf("unable to locate local ID %q", oldName)
+	}
+	refs, err := m.Rename(id, newName)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s -> %s\n", oldName, id.Name)
+	for _, ref := range refs {
+		owner := "?"
+		if ref.Owner != nil {
+			owner = ref.Owner.Name
+		}
+		fmt.Printf("unchanged reference by name in %s %s.%s: %s\n", owner, ref.Type, ref.Field, ref.Value)
+	}
+
+	if dryRun || id.Name == oldName {
+		return nil
+	}
+	return blend.EncodeFile(output, b, blend.EncodeOptions{})
+}
@@ -0,0 +1,151 @@
+// unpack is a tool which writes the files packed into a blend file to disk, and
+// relinks the blend file to refer to the written files.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/file"
+	"github.com/mewspring/blend/packed"
+)
+
+var (
+	// output is the path of the output blend file.
+	output string
+	// dryRun reports what would be unpacked without writing any file.
+	dryRun bool
+)
+
+func init() {
+	flag.Usage = usage
+	flag.StringVar(&output, "o", "", "output path (default FILE_unpacked.blend)")
+	flag.BoolVar(&dryRun, "n", false, "dry run; report what would be unpacked without writing")
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: unpack [OPTION]... FILE.blend")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Packed files are written relative to the output file (e.g. //textures/wood.png).")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Flags:")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Printf("invalid argument count.")
+		flag.Usage()
+		os.Exit(1)
+	}
+	blendPath := flag.Arg(0)
+	if output == "" {
+		output = strings.TrimSuffix(blendPath, ".blend") + "_unpacked.blend"
+	}
+
+	if err := unpack(blendPath, output, dryRun); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// unpack writes the packed files of the blend file at blendPath next to output,
+// and writes the relinked blend file to output.
+func unpack(blendPath, output string, dryRun bool) error {
+	f, err := os.Open(blendPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	decoder, err := file.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer decoder.Close()
+
+	b, err := blend.Decode(decoder)
+	if err != nil {
+		return err
+	}
+
+	dna, err := b.GetDNA()
+	if err != nil {
+		return err
+	}
+
+	files, err := packed.Files(b, dna)
+	if err != nil {
+		return err
+	}
+
+	verb := "unpacked"
+	if dryRun {
+		verb = "would unpack"
+	}
+	// Contents of the files written so far, by local path.
+	written := make(map[string][]byte)
+	for _, pf := range files {
+		data, err := pf.Bytes()
+		if err != nil {
+			return err
+		}
+		local, err := uniquePath(output, pf.LocalPath(), data, written)
+		if err != nil {
+			return err
+		}
+		written[local] = data
+
+		if !dryRun {
+			if err := pf.Extract(packed.BlenderAbs(output, local)); err != nil {
+				return err
+			}
+			if err := packed.Unpack(b, dna, pf, local); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("%s %s: %s (%d bytes)\n", verb, pf.ID(), local, len(data))
+	}
+	if dryRun {
+		fmt.Printf("%d would be unpacked\n", len(files))
+	} else {
+		fmt.Printf("%d unpacked\n", len(files))
+	}
+
+	if dryRun || len(files) == 0 {
+		return nil
+	}
+	return blend.EncodeFile(output, b, blend.EncodeOptions{})
+}
+
+// uniquePath returns local, or a variant of local with a numeric suffix if a
+// file with different contents has already been written to local, or already
+// exists on disk relative to output.
+func uniquePath(output, local string, data []byte, written map[string][]byte) (string, error) {
+	ext := filepath.Ext(local)
+	base := strings.TrimSuffix(local, ext)
+	for i := 1; ; i++ {
+		prev, ok := written[local]
+		if !ok {
+			var err error
+			prev, err = os.ReadFile(packed.BlenderAbs(output, local))
+			if os.IsNotExist(err) {
+				return local, nil
+			}
+			if err != nil {
+				return "", err
+			}
+		}
+		if bytes.Equal(prev, data) {
+			return local, nil
+		}
+		local = fmt.Sprintf("%s_%d%s", base, i, ext)
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+	"github.com/mewspring/blend/file"
+	"github.com/mewspring/blend/packed"
+)
+
+// decodeFile decodes the blend file at the given path, and returns it with its
+// DNA.
+func decodeFile(t *testing.T, path string) (*blend.Blend, *block.DNA) {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Skip(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	d, err := file.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := blend.Decode(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dna, err := b.GetDNA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, dna
+}
+
+func TestUnpack(t *testing.T) {
+	dir := t.TempDir()
+
+	// Pack a file into the image of the golden file.
+	b, dna := decodeFile(t, "../../golden/v400_uncompressed.blend")
+	var img *block.Block
+	for _, blk := range b.Blocks {
+		if blk.Hdr.Code == block.CodeIM {
+			img = blk
+			break
+		}
+	}
+	if img == nil {
+		t.Fatal("unable to locate image of golden file")
+	}
+	if err := img.ParseBody(dna); err != nil {
+		t.Fatal(err)
+	}
+	generic.Field(img.Body, "Source").SetInt(packed.ImageSourceFile)
+	data := []byte("\x89PNG\r\n\x1a\nwood")
+	if _, err := packed.Pack(b, dna, img, "/old/location/wood.png", data); err != nil {
+		t.Fatal(err)
+	}
+	blendPath := filepath.Join(dir, "packed.blend")
+	if err := blend.EncodeFile(blendPath, b, blend.EncodeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	output := filepath.Join(dir, "out", "unpacked.blend")
+	if err := os.Mkdir(filepath.Dir(output), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := unpack(blendPath, output, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// The packed file is written next to the output blend file.
+	got, err := os.ReadFile(filepath.Join(dir, "out", "textures", "wood.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("unpacked file contents mismatch; expected %q, got %q", data, got)
+	}
+
+	// The image refers to the unpacked file, which is no longer packed.
+	b, dna = decodeFile(t, output)
+	files, err := packed.Files(b, dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Errorf("expected no packed files, got %d", len(files))
+	}
+	for _, blk := range b.Blocks {
+		if blk.Hdr.Code != block.CodeIM {
+			continue
+		}
+		if err := blk.ParseBody(dna); err != nil {
+			t.Fatal(err)
+		}
+		if path := generic.FieldString(blk.Body, packed.PathFields[block.CodeIM]); path != "//textures/wood.png" {
+			t.Errorf("image path mismatch; expected %q, got %q", "//textures/wood.png", path)
+		}
+	}
+}
+
+func TestUniquePath(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "unpacked.blend")
+	written := map[string][]byte{
+		"//textures/wood.png":   []byte("a"),
+		"//textures/wood_1.png": []byte("b"),
+	}
+	// Files already on disk are not overwritten with different contents.
+	existing := filepath.Join(filepath.Dir(output), "textures", "wood_2.png")
+	if err := os.MkdirAll(filepath.Dir(existing), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(existing, []byte("c"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	golden := []struct {
+		data string
+		want string
+	}{
+		{data: "a", want: "//textures/wood.png"},
+		{data: "b", want: "//textures/wood_1.png"},
+		{data: "c", want: "//textures/wood_2.png"},
+		{data: "d", want: "//textures/wood_3.png"},
+	}
+	for _, g := range golden {
+		got, err := uniquePath(output, "//textures/wood.png", []byte(g.data), written)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != g.want {
+			t.Errorf("%q: expected %q, got %q", g.data, g.want, got)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package blend_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/file"
+)
+
+// openGolden opens the given golden file for decoding.
+func openGolden(t *testing.T, path string) *file.Reader {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Skip(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	d, err := file.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestDecodeContextProgress(t *testing.T) {
+	const path = "golden/v400_uncompressed.blend"
+	var reports []blend.Progress
+	opts := blend.DecodeOptions{
+		Progress: func(p blend.Progress) { reports = append(reports, p) },
+	}
+	b, err := blend.DecodeContext(context.Background(), openGolden(t, path), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// One report per block, including the ENDB block. Bytes are counted in the
+	// decompressed blend file.
+	if len(reports) != len(b.Blocks)+1 {
+		t.Fatalf("expected %d progress reports, got %d", len(b.Blocks)+1, len(reports))
+	}
+	for i, p := range reports {
+		if p.Blocks != i+1 || p.TotalBytes != reports[0].TotalBytes || i > 0 && p.Bytes <= reports[i-1].Bytes {
+			t.Fatalf("invalid progress report %d: %+v", i, p)
+		}
+	}
+	if last := reports[len(reports)-1]; last.Bytes != last.TotalBytes {
+		t.Errorf("progress ended at %d of %d bytes", last.Bytes, last.TotalBytes)
+	}
+
+	dna, err := b.GetDNA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var last blend.Progress
+	n := 0
+	popts := blend.ParseOptions{
+		Workers: 4,
+		Progress: func(p blend.Progress) {
+			n++
+			last = p
+		},
+	}
+	if err := b.ParseAllContext(context.Background(), dna, popts); err != nil {
+		t.Fatal(err)
+	}
+	if n != last.TotalBlocks || last.Blocks != last.TotalBlocks || last.Bytes != last.TotalBytes || last.TotalBlocks == 0 {
+		t.Errorf("invalid final progress report %+v after %d reports", last, n)
+	}
+}
+
+func TestDecodeContextCancel(t *testing.T) {
+	const path = "golden/v400_uncompressed.blend"
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := blend.DecodeContext(ctx, openGolden(t, path), blend.DecodeOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+
+	// Decoding stops between blocks once cancelled.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	n := 0
+	opts := blend.DecodeOptions{
+		Progress: func(p blend.Progress) {
+			n++
+			if p.Blocks == 10 {
+				cancel()
+			}
+		},
+	}
+	if _, err := blend.DecodeContext(ctx, openGolden(t, path), opts); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+	if n != 10 {
+		t.Errorf("expected 10 blocks read before cancellation, got %d", n)
+	}
+
+	b, dna := decodeGolden(t, path)
+	if err := b.ParseAllContext(ctx, dna, blend.ParseOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
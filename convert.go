@@ -0,0 +1,495 @@
+package blend
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+)
+
+// Convert returns a copy of b for the given byte order and pointer size; e.g.
+// to convert blend files of big-endian PowerPC-era systems to little-endian, or
+// 32-bit blend files to 64-bit. The blocks of the copy hold raw bodies in the
+// target layout, except for the DNA block which holds a copy of dna with
+// structure sizes recomputed for the target pointer size; b is left untouched.
+//
+// SDNA blocks are converted field by field, as described by the DNA rather
+// than the Go structure definitions; thus, blocks whose bodies can not be
+// parsed are converted as well. Modified bodies are encoded using the byte
+// order and pointer size of b before conversion.
+//
+// Raw DATA blocks are converted based on the fields pointing to them: pointer
+// arrays (e.g. Mesh.mat) are converted pointer by pointer, and arrays of basic
+// types (e.g. float *) are byte-swapped as needed. The remaining raw blocks
+// (e.g. void * data) have an unknown layout; they are copied unmodified, and if
+// the byte order changes, returned as unswapped blocks of b.
+//
+// As in Blender, 64-bit memory addresses of blocks are narrowed to 32 bits by
+// dropping the three low-order bits, as memory allocations are 8-byte aligned;
+// an error is returned if the narrowed addresses of two blocks collide.
+// Pointers into the middle of blocks (e.g. to an element of an array of
+// structures) are rewritten relative to the converted block, with the offset
+// rescaled for the target sizes of the structures and their fields.
+func (b *Blend) Convert(dna *block.DNA, order binary.ByteOrder, ptrSize int) (conv *Blend, unswapped []*block.Block, err error) {
+	if ptrSize != 4 && ptrSize != 8 {
+		return nil, nil, fmt.Errorf("Blend.Convert: invalid pointer size %d", ptrSize)
+	}
+	c := newConverter("Blend.Convert", b, dna, order, ptrSize)
+	return c.convert(b)
+}
+
+// newConverter returns a converter of the blocks of b to the given byte order
+// and pointer size. Errors are reported as errors of the operation op.
+func newConverter(op string, b *Blend, dna *block.DNA, order binary.ByteOrder, ptrSize int) *converter {
+	return &converter{
+		op:       op,
+		dna:      dna,
+		srcOrder: b.Hdr.Order,
+		dstOrder: order,
+		srcPtr:   b.Hdr.PtrSize,
+		dstPtr:   ptrSize,
+		layouts:  make(map[string]*layout),
+		as:       newAddrSpace(b.Blocks),
+		hints:    make(map[*block.Block]int),
+	}
+}
+
+// convert returns a converted copy of b, and the raw blocks of b of unknown
+// layout which were not converted for the target byte order.
+func (c *converter) convert(b *Blend) (conv *Blend, unswapped []*block.Block, err error) {
+	conv = &Blend{
+		Hdr:     Header{PtrSize: c.dstPtr, Order: c.dstOrder, Ver: b.Hdr.Ver},
+		Blocks:  make([]*block.Block, len(b.Blocks)),
+		OldAddr: make(map[uint64]*block.Block),
+	}
+	if c.remap == nil && c.srcPtr > c.dstPtr {
+		// Check that narrowed addresses are unique.
+		addrs := make(map[*block.Block]uint64, len(b.Blocks))
+		for _, blk := range b.Blocks {
+			addrs[blk] = c.narrow(blk.Hdr.OldAddr)
+		}
+		if err := checkRemap(c.op, c.as, addrs); err != nil {
+			return nil, nil, err
+		}
+	}
+	// Convert SDNA blocks first, to locate the raw DATA blocks referred to by
+	// their pointers.
+	var raw []int
+	for i, blk := range b.Blocks {
+		if blk.Hdr.SDNAIndex == 0 {
+			raw = append(raw, i)
+			continue
+		}
+		if conv.Blocks[i], err = c.convertBlock(blk); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, i := range raw {
+		blk := b.Blocks[i]
+		var swapped bool
+		if conv.Blocks[i], swapped, err = c.convertRaw(blk); err != nil {
+			return nil, nil, err
+		}
+		if !swapped && c.srcOrder != c.dstOrder && blk.Hdr.Size > 0 {
+			unswapped = append(unswapped, blk)
+		}
+	}
+	for _, blk := range conv.Blocks {
+		conv.OldAddr[blk.Hdr.OldAddr] = blk
+	}
+	return conv, unswapped, nil
+}
+
+// ptrArray is the element size hint of raw DATA blocks holding pointer arrays.
+const ptrArray = -1
+
+// converter converts blocks between byte orders and pointer sizes.
+type converter struct {
+	// op is the name of the operation, as reported in errors.
+	op                 string
+	dna                *block.DNA
+	srcOrder, dstOrder binary.ByteOrder
+	srcPtr, dstPtr     int
+	// layouts maps from structure type name to structure layout.
+	layouts map[string]*layout
+	// as resolves the memory addresses of pointers to blocks.
+	as *addrSpace
+	// hints maps from raw DATA block to the size of its elements as given by
+	// the fields pointing to the block; ptrArray for pointer arrays, and 0 if
+	// the fields disagree.
+	hints map[*block.Block]int
+	// remap, if non-nil, maps the memory address of a pointer stored in the
+	// block from to its address in the target, and the memory address of
+	// blocks (with from set to the block itself). Otherwise, addresses are
+	// narrowed as needed for the target pointer size.
+	remap func(from *block.Block, addr uint64) uint64
+	// zeroRuntime zeroes runtime-only fields; see RuntimeFields.
+	zeroRuntime bool
+}
+
+// A layout describes the fields of a structure.
+type layout struct {
+	fields []convField
+	// srcSize and dstSize are the structure sizes for the source and target
+	// pointer sizes.
+	srcSize, dstSize int
+}
+
+// A convField is a field of a structure layout.
+type convField struct {
+	// typ is the type name of the field.
+	typ string
+	// ptrLevel is the pointer level of the field; 0 for non-pointers.
+	ptrLevel int
+	// fn reports whether the field is a function pointer, which never refers
+	// to a block.
+	fn bool
+	// count is the number of array elements of the field; 1 for non-arrays.
+	count int
+	// size is the size of basic types; or 0 for structure types.
+	size int
+	// layout is the layout of non-pointer structure fields; or nil otherwise.
+	layout *layout
+	// srcSize and dstSize are the sizes of the field, including all array
+	// elements, for the source and target pointer sizes.
+	srcSize, dstSize int
+	// runtime reports whether the field only holds runtime data.
+	runtime bool
+}
+
+// layout returns the layout of the structure of the given type, or nil if the
+// type is not a structure.
+func (c *converter) layout(typ string) (*layout, error) {
+	if l, ok := c.layouts[typ]; ok {
+		return l, nil
+	}
+	index := c.dna.StructIndex(typ)
+	if index == -1 {
+		c.layouts[typ] = nil
+		return nil, nil
+	}
+	l := &layout{}
+	for _, f := range c.dna.Structs[index].Fields {
+		name, ptrLevel, count, err := f.Decl()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", c.op, err)
+		}
+		cf := convField{typ: f.Type, ptrLevel: ptrLevel, count: count, fn: f.IsFuncPointer(), runtime: IsRuntimeField(name)}
+		switch {
+		case c.dna.StructIndex(f.Type) == -1:
+			if cf.size = c.dna.TypeSize(f.Type); cf.size == -1 {
+				return nil, fmt.Errorf("%s: unable to locate type %q of field %q in %q", c.op, f.Type, f.Name, typ)
+			}
+		case ptrLevel == 0:
+			// Pointers to structures (possibly of the same type) need no
+			// layout.
+			if cf.layout, err = c.layout(f.Type); err != nil {
+				return nil, err
+			}
+		}
+		switch {
+		case ptrLevel > 0:
+			cf.srcSize, cf.dstSize = c.srcPtr*count, c.dstPtr*count
+		case cf.layout != nil:
+			cf.srcSize, cf.dstSize = cf.layout.srcSize*count, cf.layout.dstSize*count
+		default:
+			cf.srcSize, cf.dstSize = cf.size*count, cf.size*count
+		}
+		l.srcSize += cf.srcSize
+		l.dstSize += cf.dstSize
+		l.fields = append(l.fields, cf)
+	}
+	if size := c.dna.TypeSize(typ); size != l.srcSize {
+		return nil, fmt.Errorf("%s: size of %q computed as %d bytes, but DNA declares %d bytes", c.op, typ, l.srcSize, size)
+	}
+	c.layouts[typ] = l
+	return l, nil
+}
+
+// convertBlock converts the SDNA block blk.
+func (c *converter) convertBlock(blk *block.Block) (*block.Block, error) {
+	index := int(blk.Hdr.SDNAIndex)
+	if index >= len(c.dna.Structs) {
+		return nil, fmt.Errorf("%s: invalid SDNA index %d of %q block at %#x", c.op, index, blk.Hdr.Code, blk.Hdr.OldAddr)
+	}
+	typ := c.dna.Structs[index].Type
+	l, err := c.layout(typ)
+	if err != nil {
+		return nil, err
+	}
+	src, err := c.source(blk)
+	if err != nil {
+		return nil, err
+	}
+	if l.srcSize == 0 || len(src)%l.srcSize != 0 {
+		return nil, fmt.Errorf("%s: body of %q block at %#x (%d bytes) is not an array of %q (%d bytes)", c.op, blk.Hdr.Code, blk.Hdr.OldAddr, len(src), typ, l.srcSize)
+	}
+	n := len(src) / l.srcSize
+	dst := make([]byte, n*l.dstSize)
+	for i := 0; i < n; i++ {
+		c.convertStruct(blk, dst[i*l.dstSize:], src[i*l.srcSize:], l)
+	}
+	hdr := blk.Hdr
+	hdr.OldAddr = c.addr(blk, hdr.OldAddr)
+	hdr.Size = int64(len(dst))
+	hdr.Count = uint32(n)
+	return &block.Block{Hdr: hdr, Body: dst}, nil
+}
+
+// convertStruct converts the structure of the given layout in src to dst.
+// Pointers are resolved as stored in the block from.
+func (c *converter) convertStruct(from *block.Block, dst, src []byte, l *layout) {
+	var so, do int
+	for _, f := range l.fields {
+		if f.runtime && c.zeroRuntime {
+			// Leave zeroed.
+			so += f.srcSize
+			do += f.dstSize
+			continue
+		}
+		for i := 0; i < f.count; i++ {
+			switch {
+			case f.fn:
+				// Function pointers are only meaningful at runtime; they are
+				// zeroed if pointers are remapped.
+				var addr uint64
+				if c.remap == nil {
+					addr = c.narrow(c.readPtr(src[so:]))
+				}
+				c.writePtr(dst[do:], addr)
+				so += c.srcPtr
+				do += c.dstPtr
+			case f.ptrLevel > 0:
+				addr := c.readPtr(src[so:])
+				c.writePtr(dst[do:], c.addr(from, addr))
+				c.hint(from, addr, f)
+				so += c.srcPtr
+				do += c.dstPtr
+			case f.layout != nil:
+				c.convertStruct(from, dst[do:], src[so:], f.layout)
+				so += f.layout.srcSize
+				do += f.layout.dstSize
+			default:
+				c.swap(dst[do:do+f.size], src[so:so+f.size], f.size)
+				so += f.size
+				do += f.size
+			}
+		}
+	}
+}
+
+// hint records the element size of the raw DATA block at addr, as pointed to
+// by the pointer field f stored in the block from.
+func (c *converter) hint(from *block.Block, addr uint64, f convField) {
+	if addr == 0 {
+		return
+	}
+	blk := c.as.lookup(from, addr, true)
+	if blk == nil || blk.Hdr.Code != block.CodeDATA || blk.Hdr.SDNAIndex != 0 {
+		return
+	}
+	var size int
+	switch {
+	case f.ptrLevel > 1:
+		size = ptrArray
+	case f.layout == nil:
+		// Unknown for void pointers, as void has size 0.
+		size = f.size
+	}
+	old, ok := c.hints[blk]
+	switch {
+	case !ok, size == ptrArray:
+		c.hints[blk] = size
+	case old != ptrArray && old != size:
+		c.hints[blk] = 0
+	}
+}
+
+// convertRaw converts the block blk without SDNA index. The swapped return
+// value reports whether the layout of the block is known, and thus converted
+// for the target byte order.
+func (c *converter) convertRaw(blk *block.Block) (conv *block.Block, swapped bool, err error) {
+	hdr := blk.Hdr
+	hdr.OldAddr = c.addr(blk, hdr.OldAddr)
+	if hdr.Code == block.CodeDNA1 {
+		dna := c.targetDNA()
+		var buf bytes.Buffer
+		if err := block.WriteDNA(&buf, c.dstOrder, dna); err != nil {
+			return nil, false, err
+		}
+		hdr.Size = int64(buf.Len())
+		return &block.Block{Hdr: hdr, Body: dna}, true, nil
+	}
+
+	src, err := c.source(blk)
+	if err != nil {
+		return nil, false, err
+	}
+	dst := make([]byte, len(src))
+	copy(dst, src)
+	swapped = c.srcOrder == c.dstOrder
+	switch hdr.Code {
+	case block.CodeDATA:
+		switch size := c.hints[blk]; {
+		case size == ptrArray:
+			if len(src)%c.srcPtr != 0 {
+				return nil, false, fmt.Errorf("%s: pointer array at %#x (%d bytes) is not a multiple of the pointer size", c.op, blk.Hdr.OldAddr, len(src))
+			}
+			n := len(src) / c.srcPtr
+			dst = make([]byte, n*c.dstPtr)
+			for i := 0; i < n; i++ {
+				c.writePtr(dst[i*c.dstPtr:], c.addr(blk, c.readPtr(src[i*c.srcPtr:])))
+			}
+			swapped = true
+		case size > 0 && len(src)%size == 0:
+			c.swap(dst, src, size)
+			swapped = true
+		}
+	case block.CodeREND:
+		// Array of RenderInfo {int sfra, efra; char scene_name[64]}.
+		const size = 72
+		for off := 0; off+size <= len(src); off += size {
+			c.swap(dst[off:off+8], src[off:off+8], 4)
+		}
+		swapped = true
+	case block.CodeTEST:
+		// Thumbnail {int width, height; uint8 rgba[width*height*4]}.
+		if len(src) >= 8 {
+			c.swap(dst[:8], src[:8], 4)
+		}
+		swapped = true
+	}
+	hdr.Size = int64(len(dst))
+	return &block.Block{Hdr: hdr, Body: dst}, swapped, nil
+}
+
+// targetDNA returns a copy of the DNA with structure sizes for the target
+// pointer size.
+func (c *converter) targetDNA() *block.DNA {
+	dna := *c.dna
+	dna.TypeSizes = make([]int, len(c.dna.TypeSizes))
+	copy(dna.TypeSizes, c.dna.TypeSizes)
+	for i, typ := range dna.Types {
+		if l, err := c.layout(typ); err == nil && l != nil {
+			dna.TypeSizes[i] = l.dstSize
+		}
+	}
+	return &dna
+}
+
+// source returns the body of blk as encoded for the source byte order and
+// pointer size.
+func (c *converter) source(blk *block.Block) ([]byte, error) {
+	switch body := blk.Body.(type) {
+	case nil:
+		return blk.RawBody()
+	case []byte:
+		return body, nil
+	default:
+		var buf bytes.Buffer
+		if err := generic.Write(&buf, c.srcOrder, c.srcPtr, body); err != nil {
+			return nil, fmt.Errorf("%s: encoding %q block at %#x: %v", c.op, blk.Hdr.Code, blk.Hdr.OldAddr, err)
+		}
+		return buf.Bytes(), nil
+	}
+}
+
+// addr converts the memory address addr of a pointer stored in the block from
+// for the target.
+func (c *converter) addr(from *block.Block, addr uint64) uint64 {
+	if c.remap != nil {
+		return c.remap(from, addr)
+	}
+	if c.srcPtr == c.dstPtr || addr == 0 || addr == from.Hdr.OldAddr {
+		return c.narrow(addr)
+	}
+	loc, ok := c.as.resolve(from, addr)
+	if !ok {
+		// Dangling pointer.
+		return c.narrow(addr)
+	}
+	return c.narrow(loc.Block.Hdr.OldAddr) + uint64(c.offset(loc))
+}
+
+// narrow narrows the memory address addr for the target pointer size.
+func (c *converter) narrow(addr uint64) uint64 {
+	if c.srcPtr == 8 && c.dstPtr == 4 {
+		return uint64(uint32(addr >> 3))
+	}
+	return addr
+}
+
+// offset returns the offset of the given location within the converted block,
+// rescaled for the target sizes of the structures of the block.
+func (c *converter) offset(loc block.Location) int64 {
+	blk := loc.Block
+	if index := int(blk.Hdr.SDNAIndex); index != 0 && index < len(c.dna.Structs) {
+		l, err := c.layout(c.dna.Structs[index].Type)
+		if err != nil || l == nil || l.srcSize == 0 {
+			return loc.Offset
+		}
+		n := loc.Offset / int64(l.srcSize)
+		return n*int64(l.dstSize) + int64(l.dstOffset(int(loc.Offset%int64(l.srcSize)), c.srcPtr, c.dstPtr))
+	}
+	if blk.Hdr.Code == block.CodeDATA && c.hints[blk] == ptrArray {
+		n := loc.Offset / int64(c.srcPtr)
+		return n*int64(c.dstPtr) + min(loc.Offset%int64(c.srcPtr), int64(c.dstPtr-1))
+	}
+	return loc.Offset
+}
+
+// dstOffset returns the target offset of the given source offset within a
+// structure of the layout, for the given source and target pointer sizes.
+func (l *layout) dstOffset(off, srcPtr, dstPtr int) int {
+	var so, do int
+	for _, f := range l.fields {
+		if off >= so+f.srcSize {
+			so += f.srcSize
+			do += f.dstSize
+			continue
+		}
+		rel := off - so
+		switch {
+		case f.ptrLevel > 0:
+			return do + rel/srcPtr*dstPtr + min(rel%srcPtr, dstPtr-1)
+		case f.layout != nil && f.layout.srcSize > 0:
+			return do + rel/f.layout.srcSize*f.layout.dstSize + f.layout.dstOffset(rel%f.layout.srcSize, srcPtr, dstPtr)
+		}
+		return do + rel
+	}
+	return do + off - so
+}
+
+// readPtr reads a pointer of the source pointer size and byte order from buf.
+func (c *converter) readPtr(buf []byte) uint64 {
+	if c.srcPtr == 4 {
+		return uint64(c.srcOrder.Uint32(buf))
+	}
+	return c.srcOrder.Uint64(buf)
+}
+
+// writePtr writes a pointer of the target pointer size and byte order to buf.
+func (c *converter) writePtr(buf []byte, addr uint64) {
+	if c.dstPtr == 4 {
+		c.dstOrder.PutUint32(buf, uint32(addr))
+		return
+	}
+	c.dstOrder.PutUint64(buf, addr)
+}
+
+// swap copies the elements of the given size from src to dst, reversing the
+// bytes of each element if the byte order changes.
+func (c *converter) swap(dst, src []byte, size int) {
+	copy(dst, src)
+	if c.srcOrder == c.dstOrder || size < 2 {
+		return
+	}
+	for off := 0; off+size <= len(dst); off += size {
+		elem := dst[off : off+size]
+		for i, j := 0, size-1; i < j; i, j = i+1, j-1 {
+			elem[i], elem[j] = elem[j], elem[i]
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package blend_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+)
+
+// idNames returns the ID names of b, in block order.
+func idNames(t *testing.T, b *blend.Blend, dna *block.DNA) []string {
+	t.Helper()
+	m, err := b.Main(dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, id := range m.IDs {
+		names = append(names, id.Name)
+	}
+	return names
+}
+
+func TestConvertByteOrder(t *testing.T) {
+	b, dna := decodeGolden(t, "golden/v400_uncompressed.blend")
+	want := idNames(t, b, dna)
+	conv, _, err := b.Convert(dna, binary.BigEndian, b.Hdr.PtrSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	be, beDNA := reencode(t, conv)
+	if be.Hdr.Order != binary.BigEndian || len(be.Blocks) != len(b.Blocks) {
+		t.Fatalf("expected %d big-endian blocks, got %d %v blocks", len(b.Blocks), len(be.Blocks), be.Hdr.Order)
+	}
+	if got := idNames(t, be, beDNA); !reflect.DeepEqual(got, want) {
+		t.Errorf("ID names mismatch; expected %q, got %q", want, got)
+	}
+	if err := be.ParseAll(beDNA, 4); err != nil {
+		t.Fatal(err)
+	}
+
+	// Converting back to little-endian restores the block bodies.
+	conv, _, err = be.Convert(beDNA, binary.LittleEndian, be.Hdr.PtrSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	le, _ := reencode(t, conv)
+	if le.Hdr.Order != binary.LittleEndian || len(le.Blocks) != len(b.Blocks) {
+		t.Fatalf("expected %d little-endian blocks, got %d %v blocks", len(b.Blocks), len(le.Blocks), le.Hdr.Order)
+	}
+	for i, blk := range b.Blocks {
+		got := le.Blocks[i]
+		if got.Hdr != blk.Hdr {
+			t.Errorf("header of block %d mismatch; expected %+v, got %+v", i, blk.Hdr, got.Hdr)
+			continue
+		}
+		if blk.Hdr.Code == block.CodeDNA1 || blk.Hdr.Code == block.CodeENDB {
+			continue
+		}
+		raw, err := blk.RawBody()
+		if err != nil {
+			t.Fatal(err)
+		}
+		gotRaw, err := got.RawBody()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(gotRaw, raw) {
+			t.Errorf("body of %q block at %#x mismatch after round trip", blk.Hdr.Code, blk.Hdr.OldAddr)
+		}
+	}
+}
+
+func TestConvertPtrSize(t *testing.T) {
+	b, dna := decodeGolden(t, "golden/v400_uncompressed.blend")
+	want := idNames(t, b, dna)
+	conv, _, err := b.Convert(dna, b.Hdr.Order, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The converted blocks are not read from a file.
+	convDNA, err := conv.GetDNA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := idNames(t, conv, convDNA); !reflect.DeepEqual(names, want) {
+		t.Errorf("ID names of converted file mismatch; expected %q, got %q", want, names)
+	}
+	got, gotDNA := reencode(t, conv)
+	if got.Hdr.PtrSize != 4 || len(got.Blocks) != len(b.Blocks) {
+		t.Fatalf("expected %d blocks with 4-byte pointers, got %d blocks with %d-byte pointers", len(b.Blocks), len(got.Blocks), got.Hdr.PtrSize)
+	}
+	if names := idNames(t, got, gotDNA); !reflect.DeepEqual(names, want) {
+		t.Errorf("ID names mismatch; expected %q, got %q", want, names)
+	}
+	if err := got.ParseAll(gotDNA, 4); err != nil {
+		t.Fatal(err)
+	}
+	// Block addresses are narrowed to 32 bits, and pointers accordingly.
+	for i, blk := range got.Blocks {
+		if want := uint64(uint32(b.Blocks[i].Hdr.OldAddr >> 3)); blk.Hdr.OldAddr != want && blk.Hdr.Code != block.CodeENDB {
+			t.Errorf("address of block %d mismatch; expected %#x, got %#x", i, want, blk.Hdr.OldAddr)
+			break
+		}
+	}
+	m, err := got.Main(gotDNA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sphere := m.Lookup("OBSphere", 0)
+	if sphere == nil {
+		t.Fatal("unable to locate object \"OBSphere\"")
+	}
+	if mesh := got.OldAddr[generic.FieldAddr(sphere.Block.Body, "Data")]; mesh == nil || mesh.Hdr.Code != block.CodeME {
+		t.Errorf("data of %q does not refer to a mesh after conversion", sphere.Name)
+	}
+
+	if _, _, err := b.Convert(dna, b.Hdr.Order, 2); err == nil {
+		t.Error("expected error for invalid pointer size")
+	}
+}
@@ -0,0 +1,161 @@
+package blend_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block/generic"
+	v400 "github.com/mewspring/blend/block/v400"
+	"github.com/mewspring/blend/file"
+)
+
+// benchBlock is the raw body of an SDNA block of a golden file.
+type benchBlock struct {
+	raw   []byte
+	typ   string
+	count uint32
+	// elem is the Go type of the structures of the block.
+	elem reflect.Type
+}
+
+// loadBenchBlocks returns the SDNA blocks of the given golden file.
+func loadBenchBlocks(b testing.TB, path string) (blend.Header, []benchBlock) {
+	f, err := os.Open(path)
+	if err != nil {
+		b.Skip(err)
+	}
+	defer f.Close()
+	d, err := file.NewReader(f)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer d.Close()
+	bl, err := blend.Decode(d)
+	if err != nil {
+		b.Fatal(err)
+	}
+	dna, err := bl.GetDNA()
+	if err != nil {
+		b.Fatal(err)
+	}
+	var blks []benchBlock
+	for _, blk := range bl.Blocks {
+		if blk.Hdr.SDNAIndex == 0 {
+			continue
+		}
+		raw, err := blk.RawBody()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := blk.ParseBody(dna); err != nil {
+			b.Fatal(err)
+		}
+		elem := reflect.TypeOf(blk.Body)
+		if elem.Kind() == reflect.Slice {
+			elem = elem.Elem()
+		}
+		blks = append(blks, benchBlock{
+			raw:   raw,
+			typ:   dna.Structs[blk.Hdr.SDNAIndex].Type,
+			count: blk.Hdr.Count,
+			elem:  elem.Elem(),
+		})
+	}
+	return bl.Hdr, blks
+}
+
+// benchSize returns the total size in bytes of the given blocks.
+func benchSize(blks []benchBlock) int64 {
+	var n int64
+	for _, blk := range blks {
+		n += int64(len(blk.raw))
+	}
+	return n
+}
+
+// BenchmarkDecode decodes the SDNA blocks of the v400 golden file using the
+// generated decoders.
+func BenchmarkDecode(b *testing.B) {
+	hdr, blks := loadBenchBlocks(b, "golden/v400_uncompressed.blend")
+	b.SetBytes(benchSize(blks))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, blk := range blks {
+			if _, err := v400.ParseStructure(bytes.NewReader(blk.raw), hdr.Order, hdr.PtrSize, blk.typ, blk.count); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkDecodeReflect decodes the SDNA blocks of the v400 golden file using
+// reflection.
+func BenchmarkDecodeReflect(b *testing.B) {
+	hdr, blks := loadBenchBlocks(b, "golden/v400_uncompressed.blend")
+	b.SetBytes(benchSize(blks))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, blk := range blks {
+			r := bytes.NewReader(blk.raw)
+			for j := uint32(0); j < blk.count; j++ {
+				body := reflect.New(blk.elem).Interface()
+				if err := generic.Read(r, hdr.Order, hdr.PtrSize, body); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+}
+
+// codec is implemented by the generated Go structure definitions.
+type codec interface {
+	DecodeFrom(buf []byte, order binary.ByteOrder, ptrSize int) int
+	EncodeTo(buf []byte, order binary.ByteOrder, ptrSize int) int
+}
+
+// TestCodec checks that the generated decoders and encoders agree with the
+// reflection-based ones, and with the raw bodies of the golden files.
+func TestCodec(t *testing.T) {
+	golden := []string{
+		"golden/v305_uncompressed.blend",
+		"golden/v400_uncompressed.blend",
+	}
+	for _, path := range golden {
+		t.Run(path, func(t *testing.T) {
+			hdr, blks := loadBenchBlocks(t, path)
+			for _, blk := range blks {
+				size := generic.EncodedSize(reflect.New(blk.elem).Interface(), hdr.PtrSize)
+				for j := 0; j < int(blk.count); j++ {
+					raw := blk.raw[j*size : (j+1)*size]
+					gen := reflect.New(blk.elem).Interface().(codec)
+					if n := gen.DecodeFrom(raw, hdr.Order, hdr.PtrSize); n != size {
+						t.Fatalf("%q: decoded %d bytes; expected %d bytes", blk.typ, n, size)
+					}
+					refl := reflect.New(blk.elem).Interface().(codec)
+					if err := generic.Read(bytes.NewReader(raw), hdr.Order, hdr.PtrSize, refl); err != nil {
+						t.Fatal(err)
+					}
+					// Compare encodings rather than values, as floats may be
+					// NaN.
+					for _, body := range []codec{gen, refl} {
+						buf := make([]byte, size)
+						if n := body.EncodeTo(buf, hdr.Order, hdr.PtrSize); n != size || !bytes.Equal(buf, raw) {
+							t.Fatalf("%q: generated encoding mismatch (%d of %d bytes)", blk.typ, n, size)
+						}
+						w := new(bytes.Buffer)
+						if err := generic.Write(w, hdr.Order, hdr.PtrSize, body); err != nil {
+							t.Fatal(err)
+						}
+						if !bytes.Equal(w.Bytes(), raw) {
+							t.Fatalf("%q: reflection-based encoding mismatch", blk.typ)
+						}
+					}
+				}
+			}
+		})
+	}
+}
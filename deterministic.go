@@ -0,0 +1,73 @@
+package blend
+
+import (
+	"strings"
+
+	"github.com/mewspring/blend/block"
+)
+
+// RuntimeFields holds the names of structure fields which only hold runtime
+// data, as written by Blender from memory; e.g. the runtime structures of IDs
+// and objects, and the session UUIDs assigned when loading IDs. Fields named
+// "_pad" followed by an optional number are considered runtime fields as well.
+var RuntimeFields = map[string]bool{
+	"runtime":      true,
+	"session_uuid": true,
+	"py_instance":  true,
+}
+
+// IsRuntimeField reports whether structure fields with the given name, without
+// pointer and array notation, only hold runtime data.
+func IsRuntimeField(name string) bool {
+	if RuntimeFields[name] {
+		return true
+	}
+	if rest, ok := strings.CutPrefix(name, "_pad"); ok {
+		return strings.Trim(rest, "0123456789") == ""
+	}
+	return false
+}
+
+// deterministicBase is the memory address of the first block of deterministic
+// blend files.
+const deterministicBase = 0x1000
+
+// Deterministic returns a copy of b whose encoding only depends on the content
+// of b, so that identical content is encoded identically; e.g. for content-
+// addressed storage. The blocks of the copy hold raw bodies, as returned by
+// Convert.
+//
+// The memory addresses of blocks are renumbered in block order, and pointers
+// are rewritten accordingly; pointers which do not refer to a block of b are
+// zeroed, as they are discarded by Blender when loading. Function pointers and
+// runtime-only fields (see RuntimeFields) are zeroed. The order of blocks is
+// left untouched.
+//
+// Pointers are rewritten based on the DNA; pointers stored in raw DATA blocks
+// of unknown layout (e.g. void * data) are left untouched.
+func (b *Blend) Deterministic(dna *block.DNA) (*Blend, error) {
+	c := newConverter("Blend.Deterministic", b, dna, b.Hdr.Order, b.Hdr.PtrSize)
+	addrs := make(map[*block.Block]uint64)
+	next := uint64(deterministicBase)
+	for _, blk := range b.Blocks {
+		addrs[blk] = next
+		// Keep blocks 8-byte aligned, and distinct even if empty.
+		next += uint64(max(blk.Hdr.Size, 1)+7) &^ 7
+	}
+	c.remap = func(from *block.Block, addr uint64) uint64 {
+		if addr == 0 {
+			return 0
+		}
+		if addr == from.Hdr.OldAddr {
+			return addrs[from]
+		}
+		blk := c.as.lookup(from, addr, false)
+		if blk == nil {
+			return 0
+		}
+		return addrs[blk] + addr - blk.Hdr.OldAddr
+	}
+	c.zeroRuntime = true
+	conv, _, err := c.convert(b)
+	return conv, err
+}
@@ -0,0 +1,96 @@
+package blend_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+)
+
+// encodeDeterministic returns the deterministic encoding of b.
+func encodeDeterministic(t *testing.T, b *blend.Blend) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := blend.EncodeWithOptions(buf, b, blend.EncodeOptions{Deterministic: true}); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDeterministic(t *testing.T) {
+	for _, path := range []string{"golden/v305_uncompressed.blend", "golden/v400_uncompressed.blend"} {
+		t.Run(path, func(t *testing.T) {
+			b1, dna := decodeGolden(t, path)
+			want := idNames(t, b1, dna)
+			b2, _ := decodeGolden(t, path)
+			data := encodeDeterministic(t, b1)
+			if !bytes.Equal(encodeDeterministic(t, b2), data) {
+				t.Fatal("deterministic encodings of the same file differ")
+			}
+
+			// The deterministic encoding is a fixed point.
+			b, err := decodeBytes(t, data, blend.DecodeOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			dna, err = b.GetDNA()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(encodeDeterministic(t, b), data) {
+				t.Error("deterministic encoding not idempotent")
+			}
+
+			// The content is kept; addresses are renumbered in block order.
+			if got := idNames(t, b, dna); !reflect.DeepEqual(got, want) {
+				t.Errorf("ID names mismatch; expected %q, got %q", want, got)
+			}
+			var prev uint64
+			for i, blk := range b.Blocks {
+				if blk.Hdr.Code == block.CodeENDB {
+					continue
+				}
+				if blk.Hdr.OldAddr <= prev {
+					t.Fatalf("address %#x of block %d not in block order", blk.Hdr.OldAddr, i)
+				}
+				prev = blk.Hdr.OldAddr
+			}
+			if err := b.ParseAll(dna, 4); err != nil {
+				t.Fatal(err)
+			}
+			m, err := b.Main(dna)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sphere := m.Lookup("OBSphere", 0)
+			if sphere == nil {
+				t.Fatal("unable to locate object \"OBSphere\"")
+			}
+			if mesh := b.OldAddr[generic.FieldAddr(sphere.Block.Body, "Data")]; mesh == nil || mesh.Hdr.Code != block.CodeME {
+				t.Errorf("data of %q does not refer to a mesh after renumbering", sphere.Name)
+			}
+		})
+	}
+}
+
+func TestIsRuntimeField(t *testing.T) {
+	golden := []struct {
+		name string
+		want bool
+	}{
+		{name: "runtime", want: true},
+		{name: "session_uuid", want: true},
+		{name: "_pad", want: true},
+		{name: "_pad3", want: true},
+		{name: "_pad_0", want: false},
+		{name: "name", want: false},
+	}
+	for _, g := range golden {
+		if got := blend.IsRuntimeField(g.name); got != g.want {
+			t.Errorf("%q: expected %v, got %v", g.name, g.want, got)
+		}
+	}
+}
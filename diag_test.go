@@ -0,0 +1,97 @@
+package blend_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+)
+
+// duplicates returns the diagnostics of duplicate addresses of b.
+func duplicates(b *blend.Blend) []*block.Diagnostic {
+	var diags []*block.Diagnostic
+	for _, diag := range b.Diag.List {
+		if diag.Kind == block.DiagDuplicateAddr {
+			diags = append(diags, diag)
+		}
+	}
+	return diags
+}
+
+// withDuplicate returns the encoding of the v400 golden file, with a copy of
+// the first raw DATA block of a different size added after the given number of
+// blocks; at the end of the blocks if at is negative.
+func withDuplicate(t *testing.T, at int) []byte {
+	t.Helper()
+	b, _ := decodeGolden(t, "golden/v400_uncompressed.blend")
+	var data *block.Block
+	for _, blk := range b.Blocks {
+		if blk.Hdr.Code == block.CodeDATA && blk.Hdr.SDNAIndex == 0 {
+			data = blk
+			break
+		}
+	}
+	if data == nil {
+		t.Fatal("unable to locate DATA block of golden file")
+	}
+	dup := &block.Block{Hdr: data.Hdr, Body: make([]byte, data.Hdr.Size+8)}
+	dup.Hdr.Size += 8
+	if at < 0 {
+		// Before the DNA block, owned by the last non-DATA block.
+		at = len(b.Blocks) - 2
+	} else {
+		for i, blk := range b.Blocks {
+			if blk == data {
+				at = i + 1
+			}
+		}
+	}
+	b.Blocks = append(b.Blocks[:at], append([]*block.Block{dup}, b.Blocks[at:]...)...)
+	return encode(t, b)
+}
+
+func TestDiagnosticsGolden(t *testing.T) {
+	// DATA block addresses are only unique per owner.
+	for _, path := range []string{"golden/v305_uncompressed.blend", "golden/v400_uncompressed.blend"} {
+		b, err := blend.DecodeWithOptions(openGolden(t, path), blend.DecodeOptions{Strict: true})
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		if diags := duplicates(b); len(diags) != 0 {
+			t.Errorf("%s: expected no duplicate addresses, got %d (%v)", path, len(diags), diags[0])
+		}
+	}
+}
+
+func TestDiagnosticsDuplicate(t *testing.T) {
+	// DATA blocks of different owners may share an address.
+	b, err := decodeBytes(t, withDuplicate(t, -1), blend.DecodeOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diags := duplicates(b); len(diags) != 0 {
+		t.Errorf("expected no duplicate addresses, got %d (%v)", len(diags), diags[0])
+	}
+
+	// DATA blocks of the same owner may not.
+	data := withDuplicate(t, 0)
+	buf := new(bytes.Buffer)
+	opts := blend.DecodeOptions{Logger: slog.New(slog.NewTextHandler(buf, nil))}
+	b, err = decodeBytes(t, data, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diags := duplicates(b); len(diags) != 1 {
+		t.Errorf("expected 1 duplicate address, got %d", len(diags))
+	}
+	if !strings.Contains(buf.String(), "kind=\"duplicate address\"") {
+		t.Errorf("duplicate address not logged; got %q", buf.String())
+	}
+
+	if _, err := decodeBytes(t, data, blend.DecodeOptions{Strict: true}); err == nil || !strings.Contains(err.Error(), block.DiagDuplicateAddr.String()) {
+		t.Errorf("expected duplicate address error in strict mode, got %v", err)
+	}
+}
@@ -0,0 +1,262 @@
+package blend
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+)
+
+// addrAlign is the alignment of memory addresses allocated by NewAddr.
+const addrAlign = 16
+
+// NewAddr returns a fresh memory address for a block body of the given size.
+// The address range of the new block does not overlap with the address range
+// of any other block of b, nor with addresses previously returned by NewAddr.
+func (b *Blend) NewAddr(size int64) uint64 {
+	if b.nextAddr == 0 {
+		for _, blk := range b.Blocks {
+			if end := blk.Hdr.OldAddr + uint64(blk.Hdr.Size); end > b.nextAddr {
+				b.nextAddr = end
+			}
+		}
+	}
+	if size < 1 {
+		size = 1
+	}
+	for {
+		addr := (b.nextAddr + addrAlign - 1) &^ (addrAlign - 1)
+		b.nextAddr = addr + uint64(size)
+		if _, ok := b.OldAddr[addr]; !ok && addr != 0 {
+			return addr
+		}
+	}
+}
+
+// NewBlock returns a new block with the given code, holding count zero-valued
+// structures of the given SDNA type. The block is allocated a fresh memory
+// address but is not added to b; use InsertBlocks to add it.
+func (b *Blend) NewBlock(dna *block.DNA, code block.Code, typ string, count uint32) (*block.Block, error) {
+	index := dna.StructIndex(typ)
+	if index == -1 {
+		return nil, fmt.Errorf("Blend.NewBlock: unable to locate structure %q in DNA", typ)
+	}
+	size := int64(dna.TypeSize(typ)) * int64(count)
+
+	parser, ok := block.Versions[b.Hdr.Ver]
+	if !ok {
+		return nil, fmt.Errorf("Blend.NewBlock: version %d not supported", b.Hdr.Ver)
+	}
+	body, err := parser.ParseStructure(bytes.NewReader(make([]byte, size)), b.Hdr.Order, b.Hdr.PtrSize, typ, count)
+	if err != nil {
+		return nil, fmt.Errorf("Blend.NewBlock: %v", err)
+	}
+
+	return &block.Block{
+		Hdr: block.Header{
+			Code:      code,
+			Size:      size,
+			OldAddr:   b.NewAddr(size),
+			SDNAIndex: uint32(index),
+			Count:     count,
+		},
+		Body: body,
+	}, nil
+}
+
+// NewDataBlock returns a new DATA block holding the raw bytes of data, padded to
+// a multiple of 4 bytes as Blender does. The block is allocated a fresh memory
+// address but is not added to b; use InsertBlocks to add it.
+func (b *Blend) NewDataBlock(data []byte) *block.Block {
+	body := make([]byte, (len(data)+3)&^3)
+	copy(body, data)
+	size := int64(len(body))
+	return &block.Block{
+		Hdr: block.Header{
+			Code:    block.CodeDATA,
+			Size:    size,
+			OldAddr: b.NewAddr(size),
+			Count:   1,
+		},
+		Body: body,
+	}
+}
+
+// InsertBlocks inserts blks into b after the block after, or before the DNA
+// block if after is nil. Blender associates DATA blocks with the preceding ID
+// block, so the data of an ID should be inserted after the ID block.
+//
+// The headers of the inserted blocks are updated to match their bodies. Blocks
+// with a zero address are allocated a fresh one; it is an error for a block to
+// reuse the address of another block of b.
+func (b *Blend) InsertBlocks(dna *block.DNA, after *block.Block, blks ...*block.Block) error {
+	pos := -1
+	for i, blk := range b.Blocks {
+		if after == nil && blk.Hdr.Code == block.CodeDNA1 {
+			pos = i
+			break
+		}
+		if after != nil && blk == after {
+			pos = i + 1
+			break
+		}
+	}
+	if pos == -1 {
+		if after != nil {
+			return fmt.Errorf("Blend.InsertBlocks: unable to locate %q block at %#x", after.Hdr.Code, after.Hdr.OldAddr)
+		}
+		pos = len(b.Blocks)
+	}
+
+	for _, blk := range blks {
+		if err := blk.UpdateHeader(dna); err != nil {
+			return err
+		}
+		if blk.Hdr.OldAddr == 0 {
+			blk.Hdr.OldAddr = b.NewAddr(blk.Hdr.Size)
+		} else if other, ok := b.OldAddr[blk.Hdr.OldAddr]; ok && other != blk {
+			return fmt.Errorf("Blend.InsertBlocks: address %#x of %q block already in use by %q block", blk.Hdr.OldAddr, blk.Hdr.Code, other.Hdr.Code)
+		}
+	}
+	for _, blk := range blks {
+		b.register(blk)
+	}
+	b.Blocks = append(b.Blocks[:pos], append(append([]*block.Block(nil), blks...), b.Blocks[pos:]...)...)
+	return nil
+}
+
+// RemoveBlocks removes blks from b. Pointers to the removed blocks are left
+// untouched; use ListRemove to unlink list elements before removing them.
+func (b *Blend) RemoveBlocks(blks ...*block.Block) {
+	remove := make(map[*block.Block]bool)
+	for _, blk := range blks {
+		remove[blk] = true
+		b.unregister(blk)
+	}
+	kept := make([]*block.Block, 0, len(b.Blocks))
+	for _, blk := range b.Blocks {
+		if !remove[blk] {
+			kept = append(kept, blk)
+		}
+	}
+	b.Blocks = kept
+}
+
+// ReplaceBlock replaces the block old of b with blk. The new block takes over
+// the position and memory address of the old block, so that pointers to the
+// old block refer to the new block. The header of blk is updated to match its
+// body.
+func (b *Blend) ReplaceBlock(dna *block.DNA, old, blk *block.Block) error {
+	for i, other := range b.Blocks {
+		if other != old {
+			continue
+		}
+		if err := blk.UpdateHeader(dna); err != nil {
+			return err
+		}
+		b.unregister(old)
+		blk.Hdr.OldAddr = old.Hdr.OldAddr
+		b.register(blk)
+		b.Blocks[i] = blk
+		return nil
+	}
+	return fmt.Errorf("Blend.ReplaceBlock: unable to locate %q block at %#x", old.Hdr.Code, old.Hdr.OldAddr)
+}
+
+// register maps the memory address of blk to blk.
+func (b *Blend) register(blk *block.Block) {
+	if b.OldAddr == nil {
+		b.OldAddr = make(map[uint64]*block.Block)
+	}
+	b.OldAddr[blk.Hdr.OldAddr] = blk
+	if b.r != nil {
+		b.r.Pointers[blk.Hdr.OldAddr] = blk
+	}
+	if end := blk.Hdr.OldAddr + uint64(blk.Hdr.Size); b.nextAddr != 0 && end > b.nextAddr {
+		b.nextAddr = end
+	}
+}
+
+// unregister removes the mapping from the memory address of blk to blk.
+func (b *Blend) unregister(blk *block.Block) {
+	if b.OldAddr[blk.Hdr.OldAddr] == blk {
+		delete(b.OldAddr, blk.Hdr.OldAddr)
+	}
+	if b.r != nil && b.r.Pointers[blk.Hdr.OldAddr] == blk {
+		delete(b.r.Pointers, blk.Hdr.OldAddr)
+	}
+}
+
+// ListAppend appends the list element elem to the end of the ListBase pointed
+// to by listBase, updating the Next and Prev pointers of elem and of the
+// previous last element. The ListBase is stored in the block owner; the list
+// elements are resolved among the blocks of its owner, as described by
+// Resolver.
+func (b *Blend) ListAppend(dna *block.DNA, owner *block.Block, listBase any, elem *block.Block) error {
+	if err := elem.ParseBody(dna); err != nil {
+		return err
+	}
+	last := generic.FieldAddr(listBase, "Last")
+	if err := generic.SetFieldAddr(elem.Body, "Next", 0); err != nil {
+		return fmt.Errorf("Blend.ListAppend: %v", err)
+	}
+	if err := generic.SetFieldAddr(elem.Body, "Prev", last); err != nil {
+		return fmt.Errorf("Blend.ListAppend: %v", err)
+	}
+	if blk := newAddrSpace(b.Blocks).lookup(owner, last, true); blk != nil {
+		if err := blk.ParseBody(dna); err != nil {
+			return err
+		}
+		if err := generic.SetFieldAddr(blk.Body, "Next", elem.Hdr.OldAddr); err != nil {
+			return fmt.Errorf("Blend.ListAppend: %v", err)
+		}
+	} else if err := generic.SetFieldAddr(listBase, "First", elem.Hdr.OldAddr); err != nil {
+		return fmt.Errorf("Blend.ListAppend: %v", err)
+	}
+	if err := generic.SetFieldAddr(listBase, "Last", elem.Hdr.OldAddr); err != nil {
+		return fmt.Errorf("Blend.ListAppend: %v", err)
+	}
+	return nil
+}
+
+// ListRemove removes the list element elem from the ListBase pointed to by
+// listBase, updating the Next and Prev pointers of its neighbours. The Next and
+// Prev pointers of elem are cleared. The ListBase is stored in the block owner;
+// the list elements are resolved among the blocks of its owner, as described by
+// Resolver.
+func (b *Blend) ListRemove(dna *block.DNA, owner *block.Block, listBase any, elem *block.Block) error {
+	if err := elem.ParseBody(dna); err != nil {
+		return err
+	}
+	next := generic.FieldAddr(elem.Body, "Next")
+	prev := generic.FieldAddr(elem.Body, "Prev")
+	as := newAddrSpace(b.Blocks)
+	if blk := as.lookup(owner, prev, true); blk != nil {
+		if err := blk.ParseBody(dna); err != nil {
+			return err
+		}
+		if err := generic.SetFieldAddr(blk.Body, "Next", next); err != nil {
+			return fmt.Errorf("Blend.ListRemove: %v", err)
+		}
+	} else if err := generic.SetFieldAddr(listBase, "First", next); err != nil {
+		return fmt.Errorf("Blend.ListRemove: %v", err)
+	}
+	if blk := as.lookup(owner, next, true); blk != nil {
+		if err := blk.ParseBody(dna); err != nil {
+			return err
+		}
+		if err := generic.SetFieldAddr(blk.Body, "Prev", prev); err != nil {
+			return fmt.Errorf("Blend.ListRemove: %v", err)
+		}
+	} else if err := generic.SetFieldAddr(listBase, "Last", prev); err != nil {
+		return fmt.Errorf("Blend.ListRemove: %v", err)
+	}
+	if err := generic.SetFieldAddr(elem.Body, "Next", 0); err != nil {
+		return fmt.Errorf("Blend.ListRemove: %v", err)
+	}
+	if err := generic.SetFieldAddr(elem.Body, "Prev", 0); err != nil {
+		return fmt.Errorf("Blend.ListRemove: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package blend_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+	v400 "github.com/mewspring/blend/block/v400"
+	"github.com/mewspring/blend/file"
+)
+
+// encode returns the encoding of b.
+func encode(t *testing.T, b *blend.Blend) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := blend.Encode(buf, b); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// decodeBytes decodes the given blend file contents with the given options.
+func decodeBytes(t *testing.T, data []byte, opts blend.DecodeOptions) (*blend.Blend, error) {
+	t.Helper()
+	d, err := file.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return blend.DecodeWithOptions(d, opts)
+}
+
+// reencode encodes b and decodes the result, and returns the decoded blend file
+// with its DNA.
+func reencode(t *testing.T, b *blend.Blend) (*blend.Blend, *block.DNA) {
+	t.Helper()
+	b, err := decodeBytes(t, encode(t, b), blend.DecodeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dna, err := b.GetDNA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, dna
+}
+
+func TestEdit(t *testing.T) {
+	b, dna := decodeGolden(t, "golden/v400_uncompressed.blend")
+	n := len(b.Blocks)
+
+	// Insert a library and its data after the first block.
+	lib, err := b.NewBlock(dna, block.CodeLI, "Library", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := generic.SetFieldString(lib.Body, "Name", "//lib.blend"); err != nil {
+		t.Fatal(err)
+	}
+	data := b.NewDataBlock([]byte("hello"))
+	if data.Hdr.Size != 8 {
+		t.Errorf("size of DATA block mismatch; expected 8, got %d", data.Hdr.Size)
+	}
+	if err := b.InsertBlocks(dna, b.Blocks[0], lib, data); err != nil {
+		t.Fatal(err)
+	}
+	if b.Blocks[1] != lib || b.Blocks[2] != data {
+		t.Fatal("blocks not inserted after the first block")
+	}
+	if b.OldAddr[lib.Hdr.OldAddr] != lib || b.OldAddr[data.Hdr.OldAddr] != data {
+		t.Fatal("inserted blocks not registered by address")
+	}
+	if lib.Hdr.OldAddr == data.Hdr.OldAddr {
+		t.Errorf("inserted blocks share address %#x", lib.Hdr.OldAddr)
+	}
+	if err := b.InsertBlocks(dna, nil, &block.Block{Hdr: block.Header{Code: block.CodeDATA, OldAddr: lib.Hdr.OldAddr}, Body: []byte("abcd")}); err == nil {
+		t.Error("expected error for block reusing the address of another block")
+	}
+
+	// Replace the data with a longer body; the address is kept.
+	repl := &block.Block{Hdr: block.Header{Code: block.CodeDATA}, Body: []byte("hello, world")}
+	if err := b.ReplaceBlock(dna, data, repl); err != nil {
+		t.Fatal(err)
+	}
+	if repl.Hdr.OldAddr != data.Hdr.OldAddr || repl.Hdr.Size != 12 || b.OldAddr[repl.Hdr.OldAddr] != repl {
+		t.Errorf("replaced block mismatch; got header %+v", repl.Hdr)
+	}
+
+	// Remove the last block before the DNA block.
+	var removed *block.Block
+	for i, blk := range b.Blocks {
+		if blk.Hdr.Code == block.CodeDNA1 {
+			removed = b.Blocks[i-1]
+			break
+		}
+	}
+	b.RemoveBlocks(removed)
+	if _, ok := b.OldAddr[removed.Hdr.OldAddr]; ok {
+		t.Error("removed block still registered by address")
+	}
+	if len(b.Blocks) != n+1 {
+		t.Fatalf("expected %d blocks, got %d", n+1, len(b.Blocks))
+	}
+
+	// The edits survive re-encoding.
+	got, dna := reencode(t, b)
+	if len(got.Blocks) != len(b.Blocks) {
+		t.Fatalf("expected %d blocks after re-encoding, got %d", len(b.Blocks), len(got.Blocks))
+	}
+	for i, blk := range b.Blocks {
+		if got.Blocks[i].Hdr != blk.Hdr {
+			t.Errorf("header of block %d mismatch; expected %+v, got %+v", i, blk.Hdr, got.Blocks[i].Hdr)
+		}
+	}
+	gotLib := got.OldAddr[lib.Hdr.OldAddr]
+	if gotLib == nil || gotLib.Hdr.Code != block.CodeLI {
+		t.Fatal("unable to locate library after re-encoding")
+	}
+	if err := gotLib.ParseBody(dna); err != nil {
+		t.Fatal(err)
+	}
+	if name := generic.FieldString(gotLib.Body, "Name"); name != "//lib.blend" {
+		t.Errorf("library path mismatch; expected %q, got %q", "//lib.blend", name)
+	}
+	raw, err := got.OldAddr[repl.Hdr.OldAddr].RawBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(raw) != "hello, world" {
+		t.Errorf("DATA block mismatch; expected %q, got %q", "hello, world", raw)
+	}
+}
+
+func TestListAppendRemove(t *testing.T) {
+	b, dna := decodeGolden(t, "golden/v400_uncompressed.blend")
+	var elems []*block.Block
+	for i := 0; i < 3; i++ {
+		elem, err := b.NewBlock(dna, block.CodeDATA, "LinkData", 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		elems = append(elems, elem)
+	}
+	if err := b.InsertBlocks(dna, nil, elems...); err != nil {
+		t.Fatal(err)
+	}
+	var owner *block.Block
+	for i, blk := range b.Blocks {
+		if blk == elems[0] {
+			owner = b.Blocks[i-1]
+		}
+	}
+
+	// DATA block addresses are only unique per owner; shadow the list elements
+	// by DATA blocks of the first ID block.
+	for i, elem := range elems {
+		shadow, err := b.NewBlock(dna, block.CodeDATA, "LinkData", 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		shadow.Hdr.OldAddr = elem.Hdr.OldAddr
+		b.Blocks = append(b.Blocks[:i+2], append([]*block.Block{shadow}, b.Blocks[i+2:]...)...)
+		b.OldAddr[shadow.Hdr.OldAddr] = shadow
+	}
+
+	list := new(v400.ListBase)
+	for _, elem := range elems {
+		if err := b.ListAppend(dna, owner, list, elem); err != nil {
+			t.Fatal(err)
+		}
+	}
+	addr := func(blk *block.Block) uint64 {
+		if blk == nil {
+			return 0
+		}
+		return blk.Hdr.OldAddr
+	}
+	// check checks that the list holds the given elements in order.
+	check := func(want ...*block.Block) {
+		t.Helper()
+		var first, last *block.Block
+		if len(want) > 0 {
+			first, last = want[0], want[len(want)-1]
+		}
+		if generic.FieldAddr(list, "First") != addr(first) || generic.FieldAddr(list, "Last") != addr(last) {
+			t.Fatalf("list bounds mismatch; expected %#x..%#x, got %#x..%#x", addr(first), addr(last), generic.FieldAddr(list, "First"), generic.FieldAddr(list, "Last"))
+		}
+		for i, elem := range want {
+			var prev, next *block.Block
+			if i > 0 {
+				prev = want[i-1]
+			}
+			if i+1 < len(want) {
+				next = want[i+1]
+			}
+			if generic.FieldAddr(elem.Body, "Prev") != addr(prev) || generic.FieldAddr(elem.Body, "Next") != addr(next) {
+				t.Errorf("links of list element %d mismatch", i)
+			}
+		}
+	}
+	check(elems...)
+	if err := b.ListRemove(dna, owner, list, elems[1]); err != nil {
+		t.Fatal(err)
+	}
+	check(elems[0], elems[2])
+	if generic.FieldAddr(elems[1].Body, "Next") != 0 || generic.FieldAddr(elems[1].Body, "Prev") != 0 {
+		t.Error("links of removed list element not cleared")
+	}
+	if err := b.ListRemove(dna, owner, list, elems[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ListRemove(dna, owner, list, elems[2]); err != nil {
+		t.Fatal(err)
+	}
+	check()
+}
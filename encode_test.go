@@ -0,0 +1,64 @@
+package blend_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	v400 "github.com/mewspring/blend/block/v400"
+)
+
+func TestEncodeSyncHeader(t *testing.T) {
+	b, dna := decodeGolden(t, "golden/v400_uncompressed.blend")
+
+	// Grow a raw DATA body, and shrink an SDNA body holding several structures.
+	var data, multi *block.Block
+	for _, blk := range b.Blocks {
+		switch {
+		case data == nil && blk.Hdr.Code == block.CodeDATA && blk.Hdr.SDNAIndex == 0:
+			data = blk
+		case multi == nil && blk.Hdr.SDNAIndex != 0 && blk.Hdr.Count > 1:
+			multi = blk
+		}
+	}
+	if data == nil || multi == nil {
+		t.Fatal("unable to locate blocks of golden file")
+	}
+	raw, err := data.RawBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	grown := append(bytes.Clone(raw), "padding!"...)
+	data.Body = grown
+	if err := multi.ParseBody(dna); err != nil {
+		t.Fatal(err)
+	}
+	v := reflect.ValueOf(multi.Body)
+	if v.Kind() != reflect.Slice {
+		t.Fatalf("expected slice body of %d structures, got %T", multi.Hdr.Count, multi.Body)
+	}
+	multi.Body = v.Slice(0, 1).Interface()
+	typ := dna.Structs[multi.Hdr.SDNAIndex].Type
+
+	got, _ := reencode(t, b)
+	gotData := got.OldAddr[data.Hdr.OldAddr]
+	if gotData == nil || gotData.Hdr.Size != int64(len(grown)) {
+		t.Fatalf("size of grown DATA block mismatch; expected %d", len(grown))
+	}
+	if raw, err := gotData.RawBody(); err != nil || !bytes.Equal(raw, grown) {
+		t.Errorf("grown DATA block mismatch; %v", err)
+	}
+	gotMulti := got.OldAddr[multi.Hdr.OldAddr]
+	if gotMulti == nil || gotMulti.Hdr.Count != 1 || gotMulti.Hdr.Size != int64(dna.TypeSize(typ)) {
+		t.Fatalf("header of shrunk %q block mismatch; expected 1 structure of %d bytes", typ, dna.TypeSize(typ))
+	}
+
+	// Bodies which do not match the structure size of the header are rejected.
+	multi.Body = &v400.LinkData{}
+	if err := blend.Encode(new(bytes.Buffer), b); err == nil || !strings.Contains(err.Error(), "declares") {
+		t.Errorf("expected error for body not matching header, got %v", err)
+	}
+}
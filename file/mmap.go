@@ -0,0 +1,88 @@
+package file
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+)
+
+// Mmap is a memory-mapped file, which is read through the mapping rather than
+// through system calls. The bytes of uncompressed blend files read through a
+// Mmap are accessible without copying; see Reader.Slice.
+//
+// The mapping is read-only; bodies of blocks read through a Mmap must be copied
+// before being modified in place (see block.Block.MutableBody).
+type Mmap struct {
+	data []byte
+	// fi holds the file information of the mapped file.
+	fi fs.FileInfo
+	// off is the offset of the next Read.
+	off int64
+}
+
+// Stat returns the file information of the mapped file, as of when it was
+// mapped.
+func (m *Mmap) Stat() (fs.FileInfo, error) {
+	if m.fi == nil {
+		return nil, errors.New("file.Mmap.Stat: file information unavailable")
+	}
+	return m.fi, nil
+}
+
+// Len returns the size in bytes of the mapped file.
+func (m *Mmap) Len() int {
+	return len(m.data)
+}
+
+// Read implements io.Reader.
+func (m *Mmap) Read(p []byte) (n int, err error) {
+	if m.off >= int64(len(m.data)) {
+		return 0, io.EOF
+	}
+	n = copy(p, m.data[m.off:])
+	m.off += int64(n)
+	return n, nil
+}
+
+// ReadAt implements io.ReaderAt. It is safe to call ReadAt concurrently.
+func (m *Mmap) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.New("file.Mmap.ReadAt: negative offset")
+	}
+	if off >= int64(len(m.data)) {
+		return 0, io.EOF
+	}
+	n = copy(p, m.data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Seek implements io.Seeker.
+func (m *Mmap) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += m.off
+	case io.SeekEnd:
+		offset += int64(len(m.data))
+	default:
+		return 0, errors.New("file.Mmap.Seek: invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("file.Mmap.Seek: negative position")
+	}
+	m.off = offset
+	return offset, nil
+}
+
+// Slice returns the n bytes of the mapped file at offset off without copying,
+// and reports whether the bytes are within the file. The returned slice is only
+// valid until the Mmap is closed.
+func (m *Mmap) Slice(off, n int64) ([]byte, bool) {
+	if off < 0 || n < 0 || off > int64(len(m.data)) || n > int64(len(m.data))-off {
+		return nil, false
+	}
+	return m.data[off : off+n : off+n], true
+}
@@ -0,0 +1,53 @@
+//go:build linux
+
+package file
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// OpenMmap opens the named file for reading, and maps it into memory. The file
+// itself is never modified.
+func OpenMmap(path string) (*Mmap, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	// The mapping remains valid after closing the file.
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := fi.Size()
+	if size == 0 {
+		return &Mmap{fi: fi}, nil
+	}
+	if int64(int(size)) != size {
+		return nil, fmt.Errorf("file.OpenMmap: %q too large to map (%d bytes)", path, size)
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_PRIVATE)
+	if err != nil {
+		return nil, fmt.Errorf("file.OpenMmap: mapping %q: %v", path, err)
+	}
+	return &Mmap{data: data, fi: fi}, nil
+}
+
+// Close unmaps the file. Slices of the mapping must not be used after Close;
+// every blend file decoded from the Mmap becomes invalid, as the unmodified raw
+// bodies of its blocks are slices of the mapping. Encode the blend file before
+// closing its Mmap.
+func (m *Mmap) Close() error {
+	if m.data == nil {
+		return nil
+	}
+	data := m.data
+	m.data = nil
+	if err := syscall.Munmap(data); err != nil {
+		return fmt.Errorf("file.Mmap.Close: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+//go:build linux
+
+package file_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/file"
+)
+
+// writePlain writes an uncompressed copy of the v400 golden file to dir, and
+// returns its path.
+func writePlain(t *testing.T, dir string) string {
+	t.Helper()
+	f, err := os.Open("../golden/v400_uncompressed.blend")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer f.Close()
+	d, err := file.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := blend.Decode(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "plain.blend")
+	if err := blend.EncodeFile(path, b, blend.EncodeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// encode returns the encoding of b.
+func encode(t *testing.T, b *blend.Blend) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := blend.Encode(buf, b); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestMmap(t *testing.T) {
+	path := writePlain(t, t.TempDir())
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	fd, err := file.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := blend.Decode(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := file.OpenMmap(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	md, err := file.NewReader(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := md.Slice(0, 12); !ok {
+		t.Error("zero-copy access unsupported for mapped file")
+	}
+	if _, ok := fd.Slice(0, 12); ok {
+		t.Error("zero-copy access supported for regular file")
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mtime, ok := md.ModTime(); !ok || !mtime.Equal(fi.ModTime()) {
+		t.Errorf("modification time mismatch; expected %v, got %v", fi.ModTime(), mtime)
+	}
+	got, err := blend.Decode(md)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Blocks) != len(want.Blocks) {
+		t.Fatalf("expected %d blocks, got %d", len(want.Blocks), len(got.Blocks))
+	}
+	for i, blk := range want.Blocks {
+		if got.Blocks[i].Hdr != blk.Hdr {
+			t.Fatalf("header of block %d mismatch; expected %+v, got %+v", i, blk.Hdr, got.Blocks[i].Hdr)
+		}
+	}
+	if !bytes.Equal(encode(t, got), encode(t, want)) {
+		t.Error("encoding of mapped and regular file differ")
+	}
+
+	// Raw bodies are copied before being modified, as the mapping is
+	// read-only.
+	dna, err := got.GetDNA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data *block.Block
+	for _, blk := range got.Blocks {
+		if blk.Hdr.Code == block.CodeDATA && blk.Hdr.SDNAIndex == 0 && blk.Hdr.Size > 0 {
+			data = blk
+			break
+		}
+	}
+	if data == nil {
+		t.Fatal("unable to locate raw DATA block")
+	}
+	orig, err := data.RawBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf, err := data.MutableBody(dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf[0] ^= 0xFF
+	raw, err := data.RawBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw, orig) {
+		t.Error("mapped file modified through mutable body")
+	}
+
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Close(); err != nil {
+		t.Errorf("closing twice: %v", err)
+	}
+}
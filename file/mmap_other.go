@@ -0,0 +1,20 @@
+//go:build !linux
+
+package file
+
+import (
+	"errors"
+)
+
+// OpenMmap opens the named file for reading, and maps it into memory. Memory
+// mapping is only supported on Linux.
+func OpenMmap(path string) (*Mmap, error) {
+	return nil, errors.New("file.OpenMmap: memory mapping not supported on this platform")
+}
+
+// Close unmaps the file. Slices of the mapping must not be used after Close;
+// every blend file decoded from the Mmap becomes invalid.
+func (m *Mmap) Close() error {
+	m.data = nil
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"io/fs"
+	"time"
 
 	seekable "github.com/SaveTheRbtz/zstd-seekable-format-go"
 	"github.com/klauspost/compress/zstd"
@@ -19,6 +21,9 @@ type readSeekerAt interface {
 type Reader struct {
 	readSeekerAt
 
+	// src is the (possibly compressed) source of the file.
+	src readSeekerAt
+
 	zstdCloser       func()
 	zstdSeekerCloser func() error
 }
@@ -47,7 +52,7 @@ func NewReader(src readSeekerAt) (*Reader, error) {
 		return nil, err
 	}
 
-	var r Reader
+	r := Reader{src: src}
 
 	// File identifier.
 	magic := header[0:7]
@@ -76,6 +81,34 @@ func NewReader(src readSeekerAt) (*Reader, error) {
 	return &r, nil
 }
 
+// Slice returns the n bytes of the decompressed file at offset off without
+// copying, and reports whether zero-copy access is supported; i.e. if the file
+// is uncompressed and read through a Mmap.
+func (d *Reader) Slice(off, n int64) ([]byte, bool) {
+	s, ok := d.readSeekerAt.(interface {
+		Slice(off, n int64) ([]byte, bool)
+	})
+	if !ok {
+		return nil, false
+	}
+	return s.Slice(off, n)
+}
+
+// ModTime returns the modification time of the file, and reports whether it is
+// known; i.e. if the source of the file (e.g. an *os.File or a Mmap) provides
+// file information.
+func (d *Reader) ModTime() (time.Time, bool) {
+	s, ok := d.src.(interface{ Stat() (fs.FileInfo, error) })
+	if !ok {
+		return time.Time{}, false
+	}
+	fi, err := s.Stat()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return fi.ModTime(), true
+}
+
 func magicIsGZIP(header []byte) bool {
 	/* GZIP itself starts with the magic bytes 0x1f 0x8b.
 	 * The third byte indicates the compression method, which is 0x08 for DEFLATE. */
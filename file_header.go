@@ -70,6 +70,11 @@ func ReadHeader(r io.Reader) (hdr Header, err error) {
 	}
 
 	// Version.
+	for _, c := range buf[9:12] {
+		if c < '0' || c > '9' {
+			return hdr, fmt.Errorf("invalid version: %q", buf[9:12])
+		}
+	}
 	hdr.Ver, err = strconv.Atoi(string(buf[9:12]))
 	if err != nil {
 		return hdr, fmt.Errorf("invalid version: %s", err)
@@ -109,7 +114,10 @@ func WriteHeader(w io.Writer, hdr Header) error {
 	}
 
 	// Version.
-	copy(buf[9:12], strconv.Itoa(hdr.Ver))
+	if hdr.Ver < 0 || hdr.Ver > 999 {
+		return fmt.Errorf("invalid version: %d", hdr.Ver)
+	}
+	copy(buf[9:12], fmt.Sprintf("%03d", hdr.Ver))
 
 	_, err := w.Write(buf[:])
 	return err
@@ -0,0 +1,30 @@
+package blend
+
+import (
+	"bytes"
+	"testing"
+)
+
+func FuzzReadHeader(f *testing.F) {
+	f.Add([]byte("BLENDER-v400"))
+	f.Add([]byte("BLENDER_V305"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		hdr, err := ReadHeader(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		// A parsed header must round-trip through WriteHeader, unless its
+		// version is out of range.
+		buf := new(bytes.Buffer)
+		if err := WriteHeader(buf, hdr); err != nil {
+			return
+		}
+		got, err := ReadHeader(buf)
+		if err != nil {
+			t.Fatalf("unable to parse encoded header %q: %v", buf.String(), err)
+		}
+		if got != hdr {
+			t.Fatalf("header mismatch; expected %+v, got %+v", hdr, got)
+		}
+	})
+}
@@ -0,0 +1,334 @@
+package blend
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+)
+
+// A GCStat records the blocks of one code and SDNA type removed by GC.
+type GCStat struct {
+	// Code is the block code of the removed blocks.
+	Code block.Code
+	// Type is the SDNA type name of the removed blocks; or the empty string for
+	// raw DATA blocks.
+	Type string
+	// Blocks is the number of removed blocks.
+	Blocks int
+	// Bytes is the number of removed bytes, including block headers.
+	Bytes int64
+}
+
+func (s GCStat) String() string {
+	typ := s.Type
+	if typ == "" {
+		typ = "raw"
+	}
+	return fmt.Sprintf("%s %s: %d blocks, %d bytes", s.Code, typ, s.Blocks, s.Bytes)
+}
+
+// GC removes the blocks of b which are unreachable from the ID blocks and other
+// non-DATA blocks (e.g. GLOB) of b, and returns statistics of the removed
+// blocks sorted by code and type.
+func (b *Blend) GC(dna *block.DNA) ([]GCStat, error) {
+	blks, err := b.Unreachable(dna)
+	if err != nil {
+		return nil, err
+	}
+	stats := make(map[[2]string]*GCStat)
+	hdrSize := int64(16 + b.Hdr.PtrSize)
+	for _, blk := range blks {
+		var typ string
+		if index := int(blk.Hdr.SDNAIndex); index != 0 && index < len(dna.Structs) {
+			typ = dna.Structs[index].Type
+		}
+		key := [2]string{string(blk.Hdr.Code), typ}
+		stat, ok := stats[key]
+		if !ok {
+			stat = &GCStat{Code: blk.Hdr.Code, Type: typ}
+			stats[key] = stat
+		}
+		stat.Blocks++
+		stat.Bytes += hdrSize + blk.Hdr.Size
+	}
+	b.RemoveBlocks(blks...)
+
+	var sorted []GCStat
+	for _, stat := range stats {
+		sorted = append(sorted, *stat)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Code != sorted[j].Code {
+			return sorted[i].Code < sorted[j].Code
+		}
+		return sorted[i].Type < sorted[j].Type
+	})
+	return sorted, nil
+}
+
+// Unreachable returns the DATA blocks of b which are unreachable from the ID
+// blocks and other non-DATA blocks (e.g. GLOB) of b, in block order.
+//
+// Reachability follows the BlockPointer fields of parsed structures, and the
+// pointers stored in raw DATA blocks referred to by pointer-to-pointer fields
+// (e.g. Mesh.Mat). Pointers into the middle of a block keep the block alive.
+// Blocks which fail to parse, or whose Go structure definition does not match
+// the DNA, are scanned conservatively; every pointer-sized word which equals
+// the address of a block keeps that block alive. Unparsed bodies are decoded
+// without being retained, so that the encoding of b is unaffected.
+//
+// Pointers are resolved per owner, as described by addrSpace.
+func (b *Blend) Unreachable(dna *block.DNA) ([]*block.Block, error) {
+	m := &marker{
+		b:        b,
+		dna:      dna,
+		as:       newAddrSpace(b.Blocks),
+		marked:   make(map[*block.Block]bool),
+		arrays:   make(map[*block.Block]bool),
+		pointers: make(map[reflect.Type]bool),
+	}
+	for _, blk := range b.Blocks {
+		if blk.Hdr.Code != block.CodeDATA {
+			m.marked[blk] = true
+			m.queue = append(m.queue, blk)
+		}
+	}
+
+	for len(m.queue) > 0 {
+		blk := m.queue[0]
+		m.queue = m.queue[1:]
+		if err := m.scan(blk); err != nil {
+			return nil, err
+		}
+	}
+
+	var unreachable []*block.Block
+	for _, blk := range b.Blocks {
+		if !m.marked[blk] {
+			unreachable = append(unreachable, blk)
+		}
+	}
+	return unreachable, nil
+}
+
+// An addrSpace resolves the memory addresses of pointers to blocks. As in
+// Blender, DATA blocks belong to the preceding non-DATA block, and pointers are
+// resolved among the blocks of the same owner before the non-DATA blocks; the
+// addresses of DATA blocks are only unique per owner.
+type addrSpace struct {
+	// global holds the non-DATA blocks.
+	global *block.AddrIndex
+	// owners maps from block to the blocks of its owner.
+	owners map[*block.Block]*block.AddrIndex
+}
+
+// newAddrSpace returns the address space of the given blocks.
+func newAddrSpace(blks []*block.Block) *addrSpace {
+	as := &addrSpace{
+		owners: make(map[*block.Block]*block.AddrIndex),
+	}
+	var global []*block.Block
+	// groups holds the blocks of each owner.
+	var groups [][]*block.Block
+	for _, blk := range blks {
+		if blk.Hdr.Code != block.CodeDATA {
+			global = append(global, blk)
+			groups = append(groups, nil)
+		}
+		if len(groups) > 0 {
+			groups[len(groups)-1] = append(groups[len(groups)-1], blk)
+		}
+	}
+	as.global = block.NewAddrIndex(global)
+	for _, group := range groups {
+		sc := block.NewAddrIndex(group)
+		for _, blk := range group {
+			as.owners[blk] = sc
+		}
+	}
+	return as
+}
+
+// resolve returns the location referred to by a pointer with address addr
+// stored in from, and reports whether such a location exists.
+func (as *addrSpace) resolve(from *block.Block, addr uint64) (block.Location, bool) {
+	for _, sc := range []*block.AddrIndex{as.owners[from], as.global} {
+		if sc == nil {
+			continue
+		}
+		if loc, ok := sc.Resolve(addr); ok {
+			return loc, true
+		}
+	}
+	return block.Location{}, false
+}
+
+// lookup returns the block referred to by a pointer with address addr stored in
+// from, or nil if no such block exists. If exact is set, pointers into the
+// middle of a block are ignored.
+func (as *addrSpace) lookup(from *block.Block, addr uint64, exact bool) *block.Block {
+	if !exact {
+		loc, _ := as.resolve(from, addr)
+		return loc.Block
+	}
+	for _, sc := range []*block.AddrIndex{as.owners[from], as.global} {
+		if sc == nil {
+			continue
+		}
+		if blk, ok := sc.Lookup(addr); ok {
+			return blk
+		}
+	}
+	return nil
+}
+
+// marker marks the blocks reachable from a set of root blocks.
+type marker struct {
+	b   *Blend
+	dna *block.DNA
+	as  *addrSpace
+	// marked holds the reachable blocks.
+	marked map[*block.Block]bool
+	// queue holds the reachable blocks not yet scanned.
+	queue []*block.Block
+	// arrays holds the raw DATA blocks referred to by pointer-to-pointer
+	// fields, which store arrays of pointers.
+	arrays map[*block.Block]bool
+	// pointers caches whether values of a type contain BlockPointers.
+	pointers map[reflect.Type]bool
+}
+
+// mark marks the block referred to by a pointer with address addr stored in
+// from as reachable. ptrArray reports whether the pointer refers to an array of
+// pointers.
+func (m *marker) mark(from *block.Block, addr uint64, ptrArray, exact bool) {
+	if addr == 0 {
+		return
+	}
+	blk := m.as.lookup(from, addr, exact)
+	if blk == nil {
+		return
+	}
+	if ptrArray && !m.arrays[blk] {
+		m.arrays[blk] = true
+		if m.marked[blk] {
+			// Rescan as array of pointers.
+			m.queue = append(m.queue, blk)
+		}
+	}
+	if !m.marked[blk] {
+		m.marked[blk] = true
+		m.queue = append(m.queue, blk)
+	}
+}
+
+// scan marks the blocks referred to by blk as reachable.
+func (m *marker) scan(blk *block.Block) error {
+	if blk.Hdr.SDNAIndex == 0 {
+		if blk.Hdr.Code == block.CodeDATA && m.arrays[blk] {
+			return m.scanRaw(blk, false)
+		}
+		return nil
+	}
+	body := blk.Body
+	if body == nil {
+		// Decode the body without retaining it, so that the encoding of b is
+		// unaffected.
+		var err error
+		if body, err = blk.Decode(m.dna); err != nil {
+			return m.scanRaw(blk, true)
+		}
+	}
+	if int64(generic.EncodedSize(body, m.b.Hdr.PtrSize)) != blk.Hdr.Size {
+		// The Go structure definition does not match the DNA, so the fields
+		// of the parsed body may be misplaced.
+		return m.scanRaw(blk, true)
+	}
+	m.walk(blk, reflect.ValueOf(body))
+	return nil
+}
+
+// scanRaw marks the blocks referred to by the pointer-sized words of the raw
+// body of blk. If exact is set, only words equal to the address of a block are
+// considered pointers.
+func (m *marker) scanRaw(blk *block.Block, exact bool) error {
+	buf, ok := blk.Body.([]byte)
+	if !ok {
+		var err error
+		buf, err = blk.RawBody()
+		if err != nil {
+			return err
+		}
+	}
+	ptrSize := m.b.Hdr.PtrSize
+	for off := 0; off+ptrSize <= len(buf); off += ptrSize {
+		var addr uint64
+		if ptrSize == 4 {
+			addr = uint64(m.b.Hdr.Order.Uint32(buf[off:]))
+		} else {
+			addr = m.b.Hdr.Order.Uint64(buf[off:])
+		}
+		m.mark(blk, addr, false, exact)
+	}
+	return nil
+}
+
+// walk marks the blocks referred to by the BlockPointers of v, stored in from,
+// as reachable.
+func (m *marker) walk(from *block.Block, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			m.walk(from, v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		if !hasPointers(m.pointers, v.Type().Elem()) {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			m.walk(from, v.Index(i))
+		}
+	case reflect.Struct:
+		if generic.IsPointer(v) {
+			m.mark(from, v.Field(0).Uint(), generic.IsPointerArray(v.Type()), false)
+			return
+		}
+		if !hasPointers(m.pointers, v.Type()) {
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			m.walk(from, v.Field(i))
+		}
+	}
+}
+
+// hasPointers reports whether values of type t may contain BlockPointers. The
+// result is cached in cache.
+func hasPointers(cache map[reflect.Type]bool, t reflect.Type) bool {
+	if has, ok := cache[t]; ok {
+		return has
+	}
+	var has bool
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		has = hasPointers(cache, t.Elem())
+	case reflect.Interface:
+		has = true
+	case reflect.Struct:
+		if generic.PointerTarget(t) != nil {
+			has = true
+			break
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if hasPointers(cache, t.Field(i).Type) {
+				has = true
+				break
+			}
+		}
+	}
+	cache[t] = has
+	return has
+}
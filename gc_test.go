@@ -0,0 +1,75 @@
+package blend_test
+
+import (
+	"testing"
+
+	"github.com/mewspring/blend/block"
+)
+
+func TestGC(t *testing.T) {
+	b, dna := decodeGolden(t, "golden/v400_uncompressed.blend")
+	if _, err := b.GC(dna); err != nil {
+		t.Fatal(err)
+	}
+	b, dna = reencode(t, b)
+	n := len(b.Blocks)
+	blks, err := b.Unreachable(dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blks) != 0 {
+		t.Fatalf("expected no unreachable blocks after GC, got %d", len(blks))
+	}
+
+	// Orphaned data is removed.
+	orphan := b.NewDataBlock([]byte("orphan"))
+	if err := b.InsertBlocks(dna, nil, orphan); err != nil {
+		t.Fatal(err)
+	}
+	stats, err := b.GC(dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 1 || stats[0].Code != block.CodeDATA || stats[0].Type != "" || stats[0].Blocks != 1 {
+		t.Fatalf("expected 1 removed raw DATA block, got %v", stats)
+	}
+	if want := int64(16+b.Hdr.PtrSize) + orphan.Hdr.Size; stats[0].Bytes != want {
+		t.Errorf("removed bytes mismatch; expected %d, got %d", want, stats[0].Bytes)
+	}
+	if len(b.Blocks) != n {
+		t.Errorf("expected %d blocks, got %d", n, len(b.Blocks))
+	}
+
+	// The data of a removed ID is removed.
+	m, err := b.Main(dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mesh := m.Lookup("MEPlane", 0)
+	if mesh == nil {
+		t.Fatal("unable to locate mesh \"MEPlane\"")
+	}
+	b.RemoveBlocks(mesh.Block)
+	blks, err = b.Unreachable(dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blks) == 0 {
+		t.Fatal("expected unreachable data of removed mesh")
+	}
+	for _, blk := range blks {
+		if blk.Hdr.Code != block.CodeDATA {
+			t.Errorf("unexpected unreachable %q block at %#x", blk.Hdr.Code, blk.Hdr.OldAddr)
+		}
+	}
+	if _, err := b.GC(dna); err != nil {
+		t.Fatal(err)
+	}
+	b, dna = reencode(t, b)
+	if want := n - 1 - len(blks); len(b.Blocks) != want {
+		t.Errorf("expected %d blocks after re-encoding, got %d", want, len(b.Blocks))
+	}
+	if blks, err := b.Unreachable(dna); err != nil || len(blks) != 0 {
+		t.Errorf("expected no unreachable blocks after re-encoding, got %d (%v)", len(blks), err)
+	}
+}
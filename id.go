@@ -0,0 +1,516 @@
+package blend
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+)
+
+// An ID is an ID datablock of a blend file (e.g. an object or material).
+type ID struct {
+	// Block is the block holding the ID datablock.
+	Block *block.Block
+	// Name is the name of the ID, including its two-letter code prefix (e.g.
+	// "OBCube").
+	Name string
+	// Lib is the address of the library from which the ID is linked; or 0 if
+	// the ID is local to the blend file.
+	Lib uint64
+}
+
+// Code returns the two-letter code prefix of the ID name (e.g. "OB").
+func (id *ID) Code() string {
+	if len(id.Name) < 2 {
+		return id.Name
+	}
+	return id.Name[:2]
+}
+
+// Linked reports whether the ID is linked from a library.
+func (id *ID) Linked() bool {
+	return id.Lib != 0
+}
+
+// Main holds the ID datablocks of a blend file, as the Main database of
+// Blender.
+type Main struct {
+	// IDs holds the ID datablocks of the blend file, in block order.
+	IDs []*ID
+
+	b   *Blend
+	dna *block.DNA
+	// ids maps from block to the ID datablock held by the block.
+	ids map[*block.Block]*ID
+	// nameOffset and nameSize are the offset and size in bytes of the name
+	// field of the ID structure.
+	nameOffset, nameSize int
+}
+
+// Main returns the ID datablocks of b. Only the ID structure at the start of
+// each ID datablock is parsed, unless its body has already been parsed.
+func (b *Blend) Main(dna *block.DNA) (*Main, error) {
+	off, size, err := dna.FieldOffset("ID", "name", b.Hdr.PtrSize)
+	if err != nil {
+		return nil, fmt.Errorf("blend.Main: %v", err)
+	}
+	m := &Main{
+		b:          b,
+		dna:        dna,
+		ids:        make(map[*block.Block]*ID),
+		nameOffset: off,
+		nameSize:   size,
+	}
+	for _, blk := range b.Blocks {
+		if !IsIDCode(blk.Hdr.Code) {
+			continue
+		}
+		v, err := readID(b, dna, blk)
+		if err != nil {
+			return nil, fmt.Errorf("blend.Main: parsing %q block at %#x: %v", blk.Hdr.Code, blk.Hdr.OldAddr, err)
+		}
+		if !v.IsValid() {
+			continue
+		}
+		id := &ID{
+			Block: blk,
+			Name:  generic.FieldString(v.Addr().Interface(), "Name"),
+			Lib:   generic.PointerAddr(v.FieldByName("Lib")),
+		}
+		m.IDs = append(m.IDs, id)
+		m.ids[blk] = id
+	}
+	return m, nil
+}
+
+// IsIDCode reports whether blocks with the given code hold ID datablocks; i.e.
+// whether the code is a two-letter ID code (including the "ID" code of linked
+// ID placeholders).
+func IsIDCode(code block.Code) bool {
+	return len(code) == 4 && code[2] == 0 && code[3] == 0
+}
+
+// ID returns the ID datablock held by blk, or nil if blk holds no ID.
+func (m *Main) ID(blk *block.Block) *ID {
+	return m.ids[blk]
+}
+
+// Lookup returns the ID with the given name, including its two-letter code
+// prefix, of the library at address lib (0 for local IDs); or nil if no such ID
+// exists.
+func (m *Main) Lookup(name string, lib uint64) *ID {
+	for _, id := range m.IDs {
+		if id.Name == name && id.Lib == lib {
+			return id
+		}
+	}
+	return nil
+}
+
+// MaxNameLen returns the maximum length in bytes of ID names, excluding the
+// two-letter code prefix.
+func (m *Main) MaxNameLen() int {
+	// Reserve room for the code prefix and NUL terminator.
+	return m.nameSize - 3
+}
+
+// UniqueName returns name if no other ID than id of the same type and library
+// is named name; otherwise, the name is made unique as in Blender, by replacing
+// its numeric suffix (if any) with the lowest free suffix ".001", ".002", etc.
+// The name excludes the two-letter code prefix, and is truncated as needed to
+// make room for the suffix.
+func (m *Main) UniqueName(id *ID, name string) string {
+	used := make(map[string]bool)
+	for _, other := range m.IDs {
+		if other != id && other.Lib == id.Lib && other.Code() == id.Code() {
+			used[other.Name[2:]] = true
+		}
+	}
+	if !used[name] {
+		return name
+	}
+	base, _ := splitNumber(name)
+	for n := 1; ; n++ {
+		suffix := fmt.Sprintf(".%03d", n)
+		candidate := truncate(base, m.MaxNameLen()-len(suffix)) + suffix
+		if !used[candidate] {
+			return candidate
+		}
+	}
+}
+
+// A NameRef is a string which may refer to an ID by name, and is thus not
+// updated when the ID is renamed. Paths (e.g. the RNA path of an animation or
+// driver) refer to names in quotes, whereas name keys (e.g. the bone or vertex
+// group targeted by a constraint, modifier or driver) hold a bare name.
+type NameRef struct {
+	// Owner is the ID datablock owning the referencing structure; or nil if the
+	// owner is unknown.
+	Owner *ID
+	// Block is the block holding the referencing structure.
+	Block *block.Block
+	// Type is the SDNA type name of the referencing structure.
+	Type string
+	// Field is the name of the field which stores the string.
+	Field string
+	// Value is the string referring to the ID.
+	Value string
+}
+
+// pathFields holds the SDNA names of string fields which refer to IDs by name
+// in quotes (e.g. `objects["Cube"]`).
+var pathFields = map[string]bool{
+	"rna_path":   true,
+	"expression": true,
+}
+
+// keyFields holds the SDNA names of string fields which hold the bare name of a
+// bone or vertex group; e.g. the subtarget of a constraint, the bone of a
+// driver target, or the vertex group of a modifier.
+var keyFields = map[string]bool{
+	// Bones.
+	"subtarget":       true,
+	"polesubtarget":   true,
+	"space_subtarget": true,
+	"focus_subtarget": true,
+	"pchan_name":      true,
+	// Vertex groups.
+	"vgname":              true,
+	"vgroup":              true,
+	"vgroup_name":         true,
+	"vgroupname":          true,
+	"vgroupname_v":        true,
+	"target_vgname":       true,
+	"defgrp_name":         true,
+	"defgrp_name_a":       true,
+	"defgrp_name_b":       true,
+	"mask_defgrp_name":    true,
+	"shell_defgrp_name":   true,
+	"rim_defgrp_name":     true,
+	"anchor_grp_name":     true,
+	"source_vertex_group": true,
+}
+
+// Rename renames the local ID id to name, which excludes the two-letter code
+// prefix. The name is made unique within the IDs of the same type (see
+// UniqueName), and id.Name is updated accordingly. An error is returned if name
+// is empty, exceeds MaxNameLen bytes, or if id is linked from a library.
+//
+// Strings which may refer to the ID by its old name are left untouched, and
+// returned for the caller to review; i.e. paths quoting the old name (e.g.
+// `objects["Cube"]` in the RNA path of a driver) and bone and vertex group name
+// keys equal to the old name (see NameRef). Names stored in the bodies of
+// blocks which fail to parse are not reported.
+func (m *Main) Rename(id *ID, name string) ([]*NameRef, error) {
+	switch {
+	case id.Linked():
+		return nil, fmt.Errorf("blend.Main.Rename: unable to rename %q linked from library at %#x", id.Name, id.Lib)
+	case name == "":
+		return nil, fmt.Errorf("blend.Main.Rename: empty name for %q", id.Name)
+	case len(name) > m.MaxNameLen():
+		return nil, fmt.Errorf("blend.Main.Rename: name %q exceeds %d bytes", name, m.MaxNameLen())
+	case strings.IndexByte(name, 0) != -1:
+		return nil, fmt.Errorf("blend.Main.Rename: name %q contains NUL byte", name)
+	}
+	oldName := id.Name
+	newName := id.Code() + m.UniqueName(id, name)
+	if newName == oldName {
+		return nil, nil
+	}
+	// Collect the strings referring to the old name before renaming, so that
+	// the ID is left untouched on failure.
+	refs, err := m.nameRefs(oldName[2:])
+	if err != nil {
+		return nil, err
+	}
+	if err := m.setName(id, newName); err != nil {
+		return nil, err
+	}
+	id.Name = newName
+	return refs, nil
+}
+
+// setName stores name, including the two-letter code prefix, in the ID
+// structure of id. The body of the block is parsed as needed; raw bodies
+// ([]byte) are patched in place (see block.Block.MutableBody).
+func (m *Main) setName(id *ID, name string) error {
+	blk := id.Block
+	if _, ok := blk.Body.([]byte); ok {
+		buf, err := blk.MutableBody(m.dna)
+		if err != nil {
+			return fmt.Errorf("blend.Main.Rename: %v", err)
+		}
+		if len(buf) < m.nameOffset+m.nameSize {
+			return fmt.Errorf("blend.Main.Rename: body of %q (%d bytes) too short for ID name", id.Name, len(buf))
+		}
+		field := buf[m.nameOffset : m.nameOffset+m.nameSize]
+		n := copy(field, name)
+		clear(field[n:])
+		return nil
+	}
+	if err := blk.ParseBody(m.dna); err != nil {
+		return fmt.Errorf("blend.Main.Rename: parsing %q: %v", id.Name, err)
+	}
+	if int64(generic.EncodedSize(blk.Body, m.b.Hdr.PtrSize)) != blk.Hdr.Size {
+		// The body would be encoded with a different layout.
+		return fmt.Errorf("blend.Main.Rename: Go structure definition %T of %q does not match the DNA", blk.Body, id.Name)
+	}
+	v := idOf(blk.Body)
+	if !v.IsValid() {
+		return fmt.Errorf("blend.Main.Rename: unable to locate ID of %q in %T", id.Name, blk.Body)
+	}
+	if err := generic.SetFieldString(v.Addr().Interface(), "Name", name); err != nil {
+		return fmt.Errorf("blend.Main.Rename: %v", err)
+	}
+	return nil
+}
+
+// nameRefs returns the strings which may refer to an ID with the given name,
+// excluding its code prefix, by name; i.e. paths which contain the name in
+// quotes, and name keys equal to the name.
+func (m *Main) nameRefs(name string) ([]*NameRef, error) {
+	// As in Blender, DATA blocks belong to the preceding non-DATA block, and
+	// their addresses are only unique per owner.
+	var owner *block.Block
+	owners := make(map[*block.Block]*block.Block)
+	data := make(map[*block.Block]map[uint64]*block.Block)
+	for _, blk := range m.b.Blocks {
+		if blk.Hdr.Code != block.CodeDATA {
+			owner = blk
+			owners[blk] = blk
+			data[owner] = make(map[uint64]*block.Block)
+			continue
+		}
+		if owner != nil {
+			owners[blk] = owner
+			data[owner][blk.Hdr.OldAddr] = blk
+		}
+	}
+
+	refTypes := make(map[string]bool)
+	quoted := []string{`"` + name + `"`, `'` + name + `'`}
+	var refs []*NameRef
+	for _, blk := range m.b.Blocks {
+		index := int(blk.Hdr.SDNAIndex)
+		if index == 0 || index >= len(m.dna.Structs) {
+			continue
+		}
+		typ := m.dna.Structs[index].Type
+		if !m.hasNameRefs(refTypes, typ) {
+			continue
+		}
+		body := blk.Body
+		if body == nil {
+			// Decode the body without retaining it.
+			var err error
+			if body, err = blk.Decode(m.dna); err != nil {
+				continue
+			}
+		}
+		add := func(field, value string, match bool) {
+			if match {
+				refs = append(refs, &NameRef{
+					Owner: m.ids[owners[blk]],
+					Block: blk,
+					Type:  typ,
+					Field: field,
+					Value: value,
+				})
+			}
+		}
+		addPath := func(field, value string) {
+			add(field, value, strings.Contains(value, quoted[0]) || strings.Contains(value, quoted[1]))
+		}
+		var walk func(v reflect.Value) error
+		walk = func(v reflect.Value) error {
+			switch v.Kind() {
+			case reflect.Pointer:
+				if !v.IsNil() {
+					return walk(v.Elem())
+				}
+			case reflect.Slice, reflect.Array:
+				if v.Type().Elem().Kind() == reflect.Uint8 {
+					return nil
+				}
+				for i := 0; i < v.Len(); i++ {
+					if err := walk(v.Index(i)); err != nil {
+						return err
+					}
+				}
+			case reflect.Struct:
+				if generic.IsPointer(v) {
+					return nil
+				}
+				if rnaPath := v.FieldByName("Rna_path"); generic.IsPointer(rnaPath) {
+					if addr := generic.PointerAddr(rnaPath); addr != 0 {
+						s, err := m.dataString(data[owners[blk]], addr)
+						if err != nil {
+							return err
+						}
+						addPath("rna_path", s)
+					}
+				}
+				t := v.Type()
+				for i := 0; i < v.NumField(); i++ {
+					f := v.Field(i)
+					fieldName := strings.ToLower(t.Field(i).Name)
+					switch {
+					case isCString(f) && fieldName == "expression":
+						addPath(fieldName, generic.CString(f.Slice(0, f.Len()).Bytes()))
+					case isCString(f) && keyFields[fieldName]:
+						value := generic.CString(f.Slice(0, f.Len()).Bytes())
+						add(fieldName, value, value == name)
+					case f.Kind() == reflect.Struct,
+						f.Kind() == reflect.Array && f.Type().Elem().Kind() == reflect.Struct:
+						if err := walk(f); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			return nil
+		}
+		if err := walk(reflect.ValueOf(body)); err != nil {
+			return nil, err
+		}
+	}
+	return refs, nil
+}
+
+// hasNameRefs reports whether structures of the given SDNA type hold strings
+// which may refer to names, directly or through nested structures. The cache
+// maps from type name to the result.
+func (m *Main) hasNameRefs(cache map[string]bool, typ string) bool {
+	if has, ok := cache[typ]; ok {
+		return has
+	}
+	// Guard against recursion.
+	cache[typ] = false
+	index := m.dna.StructIndex(typ)
+	if index == -1 {
+		return false
+	}
+	for _, f := range m.dna.Structs[index].Fields {
+		name, ptrLevel, _, err := f.Decl()
+		if err != nil {
+			continue
+		}
+		if pathFields[name] || keyFields[name] || (ptrLevel == 0 && m.hasNameRefs(cache, f.Type)) {
+			cache[typ] = true
+			return true
+		}
+	}
+	return false
+}
+
+// isCString reports whether v is a fixed-size array of bytes holding a
+// NUL-terminated string.
+func isCString(v reflect.Value) bool {
+	return v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8 && v.CanAddr()
+}
+
+// dataString returns the NUL-terminated string stored in the DATA block at addr
+// among the given DATA blocks of an owner.
+func (m *Main) dataString(data map[uint64]*block.Block, addr uint64) (string, error) {
+	blk, ok := data[addr]
+	if !ok {
+		if blk, ok = m.b.OldAddr[addr]; !ok {
+			return "", fmt.Errorf("blend.Main.Rename: unable to locate string at %#x", addr)
+		}
+	}
+	buf, ok := blk.Body.([]byte)
+	if !ok {
+		var err error
+		if buf, err = blk.RawBody(); err != nil {
+			return "", fmt.Errorf("blend.Main.Rename: reading string at %#x: %v", addr, err)
+		}
+	}
+	return generic.CString(buf), nil
+}
+
+// splitNumber splits name into its base name and numeric suffix (e.g. "Cube"
+// and 1 for "Cube.001"). The number is 0 if name has no numeric suffix.
+func splitNumber(name string) (base string, n int) {
+	pos := strings.LastIndexByte(name, '.')
+	if pos == -1 || pos == len(name)-1 {
+		return name, 0
+	}
+	n, err := strconv.Atoi(name[pos+1:])
+	if err != nil || n < 0 || strings.ContainsAny(name[pos+1:], "+-") {
+		return name, 0
+	}
+	return name[:pos], n
+}
+
+// truncate truncates s to at most n bytes, without splitting UTF-8 encoded
+// characters.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// readID returns the ID structure at the start of the ID datablock blk of b; or
+// the zero Value if blk does not hold an ID datablock. Only the ID structure is
+// parsed, as the remainder of the body is not needed.
+func readID(b *Blend, dna *block.DNA, blk *block.Block) (reflect.Value, error) {
+	if _, raw := blk.Body.([]byte); blk.Body != nil && !raw {
+		return idOf(blk.Body), nil
+	}
+	index := int(blk.Hdr.SDNAIndex)
+	if index == 0 || index >= len(dna.Structs) {
+		return reflect.Value{}, nil
+	}
+	st := dna.Structs[index]
+	// Placeholders of linked IDs hold a bare ID structure.
+	if st.Type != "ID" && (len(st.Fields) == 0 || st.Fields[0].Type != "ID") {
+		return reflect.Value{}, nil
+	}
+	// Raw bodies (e.g. of converted blocks) are laid out as described by the
+	// header of b, and need not have been read from a file.
+	if buf, ok := blk.Body.([]byte); ok {
+		size := dna.TypeSize("ID")
+		if size == -1 || size > len(buf) {
+			return reflect.Value{}, fmt.Errorf("ID structure exceeds body of block at %#x (%d bytes)", blk.Hdr.OldAddr, len(buf))
+		}
+		parser, ok := block.Versions[b.Hdr.Ver]
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("version %d not supported", b.Hdr.Ver)
+		}
+		body, err := parser.ParseStructure(bytes.NewReader(buf[:size]), b.Hdr.Order, b.Hdr.PtrSize, "ID", 1)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(body).Elem(), nil
+	}
+	body, err := blk.ParsePrefix(dna, "ID")
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(body).Elem(), nil
+}
+
+// idOf returns the ID structure of the ID datablock pointed to by body; or the
+// zero Value if body is not an ID datablock.
+func idOf(body any) reflect.Value {
+	if id := generic.Field(body, "Id"); id.IsValid() {
+		return id
+	}
+	v := reflect.ValueOf(body)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	if generic.IsPointer(v.Elem().FieldByName("Lib")) && v.Elem().FieldByName("Name").IsValid() {
+		return v.Elem()
+	}
+	return reflect.Value{}
+}
@@ -0,0 +1,184 @@
+package blend_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+)
+
+func TestRename(t *testing.T) {
+	b, dna := decodeGolden(t, "golden/v400_uncompressed.blend")
+	m, err := b.Main(dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cam := m.Lookup("OBCamera", 0)
+	sphere := m.Lookup("OBSphere", 0)
+	if cam == nil || sphere == nil {
+		t.Fatal("unable to locate objects of golden file")
+	}
+	if _, err := m.Rename(cam, "Cam"); err != nil {
+		t.Fatal(err)
+	}
+	if cam.Name != "OBCam" {
+		t.Errorf("name mismatch; expected %q, got %q", "OBCam", cam.Name)
+	}
+	// The name is made unique.
+	if _, err := m.Rename(sphere, "Cam"); err != nil {
+		t.Fatal(err)
+	}
+	if sphere.Name != "OBCam.001" {
+		t.Errorf("name mismatch; expected %q, got %q", "OBCam.001", sphere.Name)
+	}
+	// Raw bodies are renamed in place.
+	area := m.Lookup("OBArea", 0)
+	if area == nil {
+		t.Fatal("unable to locate object \"OBArea\"")
+	}
+	raw, err := area.Block.RawBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	area.Block.Body = raw
+	if _, err := m.Rename(area, "Key"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"", strings.Repeat("x", m.MaxNameLen()+1), "a\x00b"} {
+		if _, err := m.Rename(cam, name); err == nil {
+			t.Errorf("expected error for name %q", name)
+		}
+	}
+
+	// The names survive re-encoding.
+	b, dna = reencode(t, b)
+	m, err = b.Main(dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := []struct {
+		name string
+		addr uint64
+	}{
+		{name: "OBCam", addr: cam.Block.Hdr.OldAddr},
+		{name: "OBCam.001", addr: sphere.Block.Hdr.OldAddr},
+		{name: "OBKey", addr: area.Block.Hdr.OldAddr},
+	}
+	for _, g := range golden {
+		id := m.Lookup(g.name, 0)
+		if id == nil {
+			t.Errorf("unable to locate %q after re-encoding", g.name)
+			continue
+		}
+		if id.Block.Hdr.OldAddr != g.addr {
+			t.Errorf("address of %q mismatch; expected %#x, got %#x", g.name, g.addr, id.Block.Hdr.OldAddr)
+		}
+	}
+	for _, name := range []string{"OBCamera", "OBSphere", "OBArea"} {
+		if m.Lookup(name, 0) != nil {
+			t.Errorf("old name %q present after re-encoding", name)
+		}
+	}
+}
+
+func TestMainAlloc(t *testing.T) {
+	// Reading the ID names of Main does not count against the total allocation
+	// limit, however often it is called.
+	const path = "golden/v400_uncompressed.blend"
+	b, _ := decodeGolden(t, path)
+	var dnaSize int64
+	for _, blk := range b.Blocks {
+		if blk.Hdr.Code == block.CodeDNA1 {
+			dnaSize = blk.Hdr.Size
+		}
+	}
+	opts := blend.DecodeOptions{Limits: block.Limits{MaxAlloc: dnaSize}}
+	b, err := blend.DecodeWithOptions(openGolden(t, path), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dna, err := b.GetDNA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := b.Main(dna); err != nil {
+			t.Fatalf("call %d: %v", i+1, err)
+		}
+	}
+}
+
+func TestRenameError(t *testing.T) {
+	b, dna := decodeGolden(t, "golden/v400_uncompressed.blend")
+	m, err := b.Main(dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cam := m.Lookup("OBCamera", 0)
+	if cam == nil {
+		t.Fatal("unable to locate object \"OBCamera\"")
+	}
+	// An RNA path which can not be resolved.
+	fcu, err := b.NewBlock(dna, block.CodeDATA, "FCurve", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := generic.SetFieldAddr(fcu.Body, "Rna_path", b.NewAddr(8)); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.InsertBlocks(dna, cam.Block, fcu); err != nil {
+		t.Fatal(err)
+	}
+
+	// The ID is left untouched by a failed rename.
+	if _, err := m.Rename(cam, "Cam"); err == nil {
+		t.Fatal("expected error for unresolvable RNA path")
+	}
+	if cam.Name != "OBCamera" {
+		t.Errorf("name mismatch; expected %q, got %q", "OBCamera", cam.Name)
+	}
+	if names := idNames(t, b, dna); !slices.Contains(names, "OBCamera") {
+		t.Errorf("name of %q changed by failed rename", "OBCamera")
+	}
+}
+
+func TestUniqueName(t *testing.T) {
+	b, dna := decodeGolden(t, "golden/v400_uncompressed.blend")
+	m, err := b.Main(dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cam := m.Lookup("OBCamera", 0)
+	if cam == nil {
+		t.Fatal("unable to locate object \"OBCamera\"")
+	}
+	long := strings.Repeat("x", m.MaxNameLen())
+	golden := []struct {
+		name, want string
+	}{
+		{name: "Camera", want: "Camera"},
+		{name: "Cube", want: "Cube"},
+		{name: "Area", want: "Area.005"},
+		{name: "Area.002", want: "Area.005"},
+		// Names of other ID types do not collide.
+		{name: "Plane", want: "Plane"},
+		{name: long, want: long},
+	}
+	for _, g := range golden {
+		if got := m.UniqueName(cam, g.name); got != g.want {
+			t.Errorf("%q: expected %q, got %q", g.name, g.want, got)
+		}
+	}
+	// Names are truncated to make room for the suffix.
+	area := m.Lookup("OBArea", 0)
+	if _, err := m.Rename(area, long); err != nil {
+		t.Fatal(err)
+	}
+	if want := long[:len(long)-4] + ".001"; m.UniqueName(cam, long) != want {
+		t.Errorf("%q: expected %q, got %q", long, want, m.UniqueName(cam, long))
+	}
+}
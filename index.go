@@ -0,0 +1,246 @@
+package blend
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/file"
+)
+
+// An Index locates the file blocks of a blend file, so that the blend file may
+// be decoded without reading the headers of its blocks; see DecodeIndexed.
+//
+// Building an index reads every block header of the blend file, as Decode does.
+// The index may thereafter be stored in a sidecar file (see Index.WriteTo and
+// ReadIndex), so that reopening a large blend file only reads the blocks which
+// are accessed.
+type Index struct {
+	// Hdr is the header of the blend file.
+	Hdr Header
+	// Size is the size in bytes of the (decompressed) blend file, which is used
+	// to detect stale indices.
+	Size int64
+	// ModTime is the modification time of the blend file, which is used to
+	// detect stale indices; or the zero time if unknown.
+	ModTime time.Time
+	// Entries locates the file blocks in file order, excluding the ENDB block.
+	Entries []block.IndexEntry
+}
+
+// BuildIndex reads the block headers of the blend file read by d, and returns
+// an index of its file blocks.
+func BuildIndex(d *file.Reader) (*Index, error) {
+	idx := new(Index)
+	var err error
+	idx.Hdr, err = ReadHeader(d)
+	if err != nil {
+		return nil, fmt.Errorf("blend.BuildIndex: reading header: %v", err)
+	}
+	r := block.NewReader(idx.Hdr.Order, idx.Hdr.PtrSize, idx.Hdr.Ver)
+	err = r.Walk(d, nil, block.Filter{}, func(blk *block.Block) error {
+		entry, _ := blk.IndexEntry()
+		idx.Entries = append(idx.Entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("blend.BuildIndex: %v", err)
+	}
+	if idx.Size, err = d.Seek(0, io.SeekEnd); err != nil {
+		return nil, fmt.Errorf("blend.BuildIndex: %v", err)
+	}
+	if modTime, ok := d.ModTime(); ok {
+		idx.ModTime = modTime
+	}
+	return idx, nil
+}
+
+// DecodeIndexed decodes the blend file read by d as DecodeWithOptions does,
+// but takes the file blocks from the given index instead of reading their
+// headers; block bodies are read from d when parsed. An error is returned if
+// the header, size or modification time of the blend file does not match the
+// index, or if an index entry extends past the end of the blend file.
+//
+// As block headers are not read, modifications of the blend file which keep
+// its size and modification time are only detected once a block is read: the
+// header of each block is checked against its index entry when the body of the
+// block is first read (see block.Reader.BlockAt).
+func DecodeIndexed(d *file.Reader, idx *Index, opts DecodeOptions) (*Blend, error) {
+	size, err := d.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	if size != idx.Size {
+		return nil, fmt.Errorf("blend.DecodeIndexed: size of blend file (%d bytes) does not match index (%d bytes)", size, idx.Size)
+	}
+	if modTime, ok := d.ModTime(); ok && !idx.ModTime.IsZero() && !modTime.Equal(idx.ModTime) {
+		return nil, fmt.Errorf("blend.DecodeIndexed: modification time of blend file (%v) does not match index (%v)", modTime, idx.ModTime)
+	}
+	if _, err := d.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	b := &Blend{
+		OldAddr: make(map[uint64]*block.Block, len(idx.Entries)),
+		Diag:    &block.Diagnostics{Strict: opts.Strict, Logger: opts.Logger},
+	}
+
+	// Parse file header.
+	b.Hdr, err = ReadHeader(d)
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %v", err)
+	}
+	if b.Hdr != idx.Hdr {
+		return nil, fmt.Errorf("blend.DecodeIndexed: header of blend file (%+v) does not match index (%+v)", b.Hdr, idx.Hdr)
+	}
+
+	blkReader := block.NewReaderWithDiagnostics(b.Hdr.Order, b.Hdr.PtrSize, b.Hdr.Ver, b.Diag)
+	blkReader.Limits = opts.Limits
+	b.r = blkReader
+	progress := Progress{TotalBlocks: len(idx.Entries), TotalBytes: idx.Size}
+	b.Blocks = make([]*block.Block, 0, len(idx.Entries))
+	hdrSize := int64(block.HeaderSize(b.Hdr.PtrSize))
+	for _, entry := range idx.Entries {
+		if entry.Offset < 0 || entry.Hdr.Size < 0 || entry.Offset > size-hdrSize-entry.Hdr.Size {
+			return nil, fmt.Errorf("blend.DecodeIndexed: %q block at offset %d (%d bytes) extends past end of blend file (%d bytes)", entry.Hdr.Code, entry.Offset, entry.Hdr.Size, size)
+		}
+		blk, err := blkReader.BlockAt(d, entry)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Progress != nil {
+			progress.Blocks++
+			progress.Bytes = entry.Offset + hdrSize + entry.Hdr.Size
+			opts.Progress(progress)
+		}
+		b.OldAddr[blk.Hdr.OldAddr] = blk
+		b.Blocks = append(b.Blocks, blk)
+	}
+
+	return b, nil
+}
+
+// Sidecar file format of indices.
+//
+//	magic    "BLENDIDX"
+//	version  uint32
+//	header   blend file header (12 bytes)
+//	size     int64
+//	mod time int64 (nanoseconds since the Unix epoch; 0 if unknown)
+//	count    uint64
+//	entries  [count]entry
+//
+// with each entry encoded as
+//
+//	code       [4]byte
+//	offset     int64
+//	size       int64
+//	old addr   uint64
+//	sdna index uint32
+//	count      uint32
+//
+// Integers are stored in little-endian byte order.
+const (
+	indexMagic     = "BLENDIDX"
+	indexVersion   = 2
+	indexEntrySize = 36
+)
+
+// WriteTo writes the index to w in the sidecar file format read by ReadIndex.
+func (idx *Index) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	buf.WriteString(indexMagic)
+	order := binary.LittleEndian
+	var tmp [8]byte
+	order.PutUint32(tmp[:4], indexVersion)
+	buf.Write(tmp[:4])
+	if err := WriteHeader(&buf, idx.Hdr); err != nil {
+		return 0, fmt.Errorf("Index.WriteTo: %v", err)
+	}
+	order.PutUint64(tmp[:], uint64(idx.Size))
+	buf.Write(tmp[:])
+	var modTime int64
+	if !idx.ModTime.IsZero() {
+		modTime = idx.ModTime.UnixNano()
+	}
+	order.PutUint64(tmp[:], uint64(modTime))
+	buf.Write(tmp[:])
+	order.PutUint64(tmp[:], uint64(len(idx.Entries)))
+	buf.Write(tmp[:])
+	for _, entry := range idx.Entries {
+		var e [indexEntrySize]byte
+		if len(entry.Hdr.Code) != 4 {
+			return 0, fmt.Errorf("Index.WriteTo: invalid block code %q", entry.Hdr.Code)
+		}
+		copy(e[0:4], entry.Hdr.Code)
+		order.PutUint64(e[4:], uint64(entry.Offset))
+		order.PutUint64(e[12:], uint64(entry.Hdr.Size))
+		order.PutUint64(e[20:], entry.Hdr.OldAddr)
+		order.PutUint32(e[28:], entry.Hdr.SDNAIndex)
+		order.PutUint32(e[32:], entry.Hdr.Count)
+		buf.Write(e[:])
+	}
+	return buf.WriteTo(w)
+}
+
+// ReadIndex reads an index in the sidecar file format written by
+// Index.WriteTo.
+func ReadIndex(r io.Reader) (*Index, error) {
+	var magic [len(indexMagic) + 4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return nil, fmt.Errorf("blend.ReadIndex: reading magic: %v", err)
+	}
+	if string(magic[:len(indexMagic)]) != indexMagic {
+		return nil, errors.New("blend.ReadIndex: invalid magic; not an index file")
+	}
+	order := binary.LittleEndian
+	if version := order.Uint32(magic[len(indexMagic):]); version != indexVersion {
+		return nil, fmt.Errorf("blend.ReadIndex: unsupported index version %d", version)
+	}
+	idx := new(Index)
+	var err error
+	idx.Hdr, err = ReadHeader(r)
+	if err != nil {
+		return nil, fmt.Errorf("blend.ReadIndex: reading header: %v", err)
+	}
+	var tmp [24]byte
+	if _, err := io.ReadFull(r, tmp[:]); err != nil {
+		return nil, fmt.Errorf("blend.ReadIndex: %v", err)
+	}
+	idx.Size = int64(order.Uint64(tmp[:]))
+	if modTime := int64(order.Uint64(tmp[8:])); modTime != 0 {
+		idx.ModTime = time.Unix(0, modTime)
+	}
+	n := order.Uint64(tmp[16:])
+	if idx.Size < 0 || n > uint64(idx.Size) {
+		return nil, fmt.Errorf("blend.ReadIndex: invalid index of %d blocks in %d bytes", n, idx.Size)
+	}
+	hdrSize := int64(block.HeaderSize(idx.Hdr.PtrSize))
+	// Grow the entries as they are read, so that a corrupt count does not cause
+	// a large allocation.
+	for i := uint64(0); i < n; i++ {
+		var e [indexEntrySize]byte
+		if _, err := io.ReadFull(r, e[:]); err != nil {
+			return nil, fmt.Errorf("blend.ReadIndex: reading entry %d: %v", i, err)
+		}
+		entry := block.IndexEntry{
+			Offset: int64(order.Uint64(e[4:])),
+			Hdr: block.Header{
+				Code:      block.Code(e[0:4]),
+				Size:      int64(order.Uint64(e[12:])),
+				OldAddr:   order.Uint64(e[20:]),
+				SDNAIndex: order.Uint32(e[28:]),
+				Count:     order.Uint32(e[32:]),
+			},
+		}
+		if entry.Offset < 0 || entry.Hdr.Size < 0 || entry.Offset > idx.Size-hdrSize-entry.Hdr.Size {
+			return nil, fmt.Errorf("blend.ReadIndex: entry %d (offset %d, size %d) outside of blend file", i, entry.Offset, entry.Hdr.Size)
+		}
+		idx.Entries = append(idx.Entries, entry)
+	}
+	return idx, nil
+}
@@ -0,0 +1,191 @@
+package blend_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/file"
+)
+
+// writeIndexed writes an uncompressed copy of the v400 golden file to dir, and
+// returns its path and index.
+func writeIndexed(t *testing.T, dir string) (string, *blend.Index) {
+	t.Helper()
+	b, _ := decodeGolden(t, "golden/v400_uncompressed.blend")
+	path := filepath.Join(dir, "indexed.blend")
+	if err := blend.EncodeFile(path, b, blend.EncodeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	d, err := file.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx, err := blend.BuildIndex(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path, idx
+}
+
+// decodeIndexed decodes the blend file at the given path using idx.
+func decodeIndexed(t *testing.T, path string, idx *blend.Index) (*blend.Blend, error) {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	d, err := file.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return blend.DecodeIndexed(d, idx, blend.DecodeOptions{})
+}
+
+func TestIndex(t *testing.T) {
+	path, idx := writeIndexed(t, t.TempDir())
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idx.Size != fi.Size() || !idx.ModTime.Equal(fi.ModTime()) {
+		t.Errorf("index of %d bytes modified at %v does not match file of %d bytes modified at %v", idx.Size, idx.ModTime, fi.Size(), fi.ModTime())
+	}
+
+	// The index survives a round trip through the sidecar file format.
+	buf := new(bytes.Buffer)
+	if _, err := idx.WriteTo(buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := blend.ReadIndex(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Hdr != idx.Hdr || got.Size != idx.Size || !got.ModTime.Equal(idx.ModTime) || !reflect.DeepEqual(got.Entries, idx.Entries) {
+		t.Fatal("index mismatch after round trip")
+	}
+	if _, err := blend.ReadIndex(strings.NewReader("BLENDIDY")); err == nil {
+		t.Error("expected error for invalid magic")
+	}
+
+	// Indexed decoding matches regular decoding.
+	b, err := decodeIndexed(t, path, got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	d, err := file.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := blend.Decode(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var x, y bytes.Buffer
+	if err := blend.Encode(&x, b); err != nil {
+		t.Fatal(err)
+	}
+	if err := blend.Encode(&y, want); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(x.Bytes(), y.Bytes()) {
+		t.Error("encoding of indexed and regular decoding differ")
+	}
+}
+
+func TestIndexStale(t *testing.T) {
+	path, idx := writeIndexed(t, t.TempDir())
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// write writes the blend file and sets its modification time.
+	write := func(data []byte, mtime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Size and modification time are checked when decoding.
+	write(append(bytes.Clone(data), 0), fi.ModTime())
+	if _, err := decodeIndexed(t, path, idx); err == nil || !strings.Contains(err.Error(), "size") {
+		t.Errorf("expected size mismatch error, got %v", err)
+	}
+	write(data, fi.ModTime().Add(time.Second))
+	if _, err := decodeIndexed(t, path, idx); err == nil || !strings.Contains(err.Error(), "modification time") {
+		t.Errorf("expected modification time mismatch error, got %v", err)
+	}
+
+	// Entries past the end of the blend file are rejected.
+	write(data, fi.ModTime())
+	past := *idx
+	past.Entries = append([]block.IndexEntry(nil), idx.Entries...)
+	past.Entries[len(past.Entries)-1].Offset = idx.Size
+	if _, err := decodeIndexed(t, path, &past); err == nil || !strings.Contains(err.Error(), "past end") {
+		t.Errorf("expected entry past end of file error, got %v", err)
+	}
+	buf := new(bytes.Buffer)
+	if _, err := past.WriteTo(buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := blend.ReadIndex(buf); err == nil {
+		t.Error("expected error reading entry past end of file")
+	}
+
+	// Block headers modified in place are detected when the block is read.
+	var entry block.IndexEntry
+	i := -1
+	for j, e := range idx.Entries {
+		if e.Hdr.Code == block.CodeOB {
+			entry, i = e, j
+			break
+		}
+	}
+	if i == -1 {
+		t.Fatal("unable to locate object block")
+	}
+	stale := bytes.Clone(data)
+	// Patch the structure count of the block header.
+	off := entry.Offset + int64(block.HeaderSize(idx.Hdr.PtrSize)) - 4
+	stale[off]++
+	write(stale, fi.ModTime())
+	b, err := decodeIndexed(t, path, idx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dna, err := b.GetDNA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Blocks[i].ParseBody(dna); err == nil || !strings.Contains(err.Error(), "stale index") {
+		t.Errorf("expected stale index error, got %v", err)
+	}
+	if err := blend.Encode(new(bytes.Buffer), b); err == nil {
+		t.Error("expected error encoding blend file with stale index")
+	}
+}
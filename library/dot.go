@@ -0,0 +1,58 @@
+package library
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// WriteDOT writes the graph to w in the DOT format of Graphviz. Files are
+// labelled by base name; missing files and files which failed to decode are
+// drawn in red, as are libraries with missing IDs. Edges are labelled with the
+// names of the linked IDs.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph libraries {")
+	fmt.Fprintln(bw, "\tnode [shape=box];")
+	for _, f := range g.Files {
+		tooltip := f.Path
+		var attrs []string
+		switch {
+		case f.Missing:
+			attrs = append(attrs, "color=red", "style=dashed")
+		case f.Error != "":
+			attrs = append(attrs, "color=red")
+			tooltip += "\n" + f.Error
+		}
+		attrs = append(attrs, "label="+quote(filepath.Base(f.Path)), "tooltip="+quote(tooltip))
+		if f.Path == g.Root {
+			attrs = append(attrs, "peripheries=2")
+		}
+		fmt.Fprintf(bw, "\t%s [%s];\n", quote(f.Path), strings.Join(attrs, ", "))
+	}
+	for _, f := range g.Files {
+		for _, lib := range f.Libraries {
+			var attrs []string
+			ids := lib.IDs
+			if len(lib.MissingIDs) > 0 {
+				attrs = append(attrs, "color=red")
+				ids = append(append([]string(nil), ids...), "missing: "+strings.Join(lib.MissingIDs, ", "))
+			}
+			if lib.Indirect {
+				attrs = append(attrs, "style=dashed")
+			}
+			attrs = append(attrs, "label="+quote(strings.Join(ids, "\n")))
+			fmt.Fprintf(bw, "\t%s -> %s [%s];\n", quote(f.Path), quote(lib.Path), strings.Join(attrs, ", "))
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// quote returns s as a quoted DOT string.
+func quote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
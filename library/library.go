@@ -0,0 +1,238 @@
+// Package library resolves the dependencies between blend files introduced by
+// linked libraries.
+package library
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+	"github.com/mewspring/blend/file"
+	"github.com/mewspring/blend/packed"
+)
+
+// A Graph is the dependency graph of blend files linked through libraries.
+type Graph struct {
+	// Root is the absolute path of the blend file from which the graph was
+	// resolved.
+	Root string `json:"root"`
+	// Files holds the blend files of the graph, in breadth-first order starting
+	// with the root file.
+	Files []*File `json:"files"`
+	// Cycles holds the cycles of the graph; each cycle is a list of file paths
+	// starting and ending with the same file.
+	Cycles [][]string `json:"cycles,omitempty"`
+
+	// files maps from absolute file path to the files of the graph.
+	files map[string]*File
+}
+
+// A File is a blend file of a dependency graph.
+type File struct {
+	// Path is the absolute path of the blend file.
+	Path string `json:"path"`
+	// Missing reports whether the blend file does not exist.
+	Missing bool `json:"missing,omitempty"`
+	// Error is the error encountered while reading the blend file, if any.
+	Error string `json:"error,omitempty"`
+	// Libraries holds the libraries linked by the blend file, in block order.
+	Libraries []*Library `json:"libraries,omitempty"`
+
+	// ids holds the names of the IDs local to the blend file, including their
+	// two-letter code prefix.
+	ids map[string]bool
+}
+
+// A Library is a library linked by a blend file.
+type Library struct {
+	// Name is the file path of the library, as stored in the blend file (i.e.
+	// possibly relative to the blend file with a "//" prefix).
+	Name string `json:"name"`
+	// Path is the absolute path of the library file.
+	Path string `json:"path"`
+	// Indirect reports whether the library is only linked indirectly, through
+	// another library.
+	Indirect bool `json:"indirect,omitempty"`
+	// IDs holds the sorted names of the IDs linked from the library, including
+	// their two-letter code prefix.
+	IDs []string `json:"ids,omitempty"`
+	// MissingIDs holds the sorted names of linked IDs which are not present in
+	// the library file.
+	MissingIDs []string `json:"missing_ids,omitempty"`
+}
+
+// Resolve returns the dependency graph of the blend file located at path,
+// following the file paths of its libraries recursively. Missing library files
+// and IDs, as well as libraries which fail to decode, are recorded in the graph
+// rather than reported as errors. An error is only returned if the root file
+// can not be read.
+func Resolve(path string) (*Graph, error) {
+	root, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	g := &Graph{Root: root, files: make(map[string]*File)}
+	queue := []string{root}
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		if _, ok := g.files[path]; ok {
+			continue
+		}
+		f, err := readFile(path)
+		if err != nil {
+			if path == root {
+				return nil, err
+			}
+			f.Error = err.Error()
+		}
+		g.files[path] = f
+		g.Files = append(g.Files, f)
+		for _, lib := range f.Libraries {
+			queue = append(queue, lib.Path)
+		}
+	}
+
+	// Check the linked IDs against the IDs of the library files.
+	for _, f := range g.Files {
+		for _, lib := range f.Libraries {
+			libFile := g.files[lib.Path]
+			if libFile.Missing || libFile.Error != "" {
+				continue
+			}
+			for _, id := range lib.IDs {
+				if !libFile.ids[id] {
+					lib.MissingIDs = append(lib.MissingIDs, id)
+				}
+			}
+		}
+	}
+	g.Cycles = g.findCycles()
+	return g, nil
+}
+
+// File returns the file of the graph located at the given absolute path, or
+// nil if not present.
+func (g *Graph) File(path string) *File {
+	return g.files[path]
+}
+
+// readFile reads the libraries and local IDs of the blend file located at path.
+// The returned file is non-nil, even if an error is returned.
+func readFile(path string) (*File, error) {
+	f := &File{Path: path, ids: make(map[string]bool)}
+	r, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		f.Missing = true
+		return f, nil
+	} else if err != nil {
+		return f, err
+	}
+	defer r.Close()
+
+	decoder, err := file.NewReader(r)
+	if err != nil {
+		return f, err
+	}
+	defer decoder.Close()
+
+	b, err := blend.Decode(decoder)
+	if err != nil {
+		return f, err
+	}
+	dna, err := b.GetDNA()
+	if err != nil {
+		return f, err
+	}
+
+	m, err := b.Main(dna)
+	if err != nil {
+		return f, fmt.Errorf("library.Resolve: reading IDs of %q: %v", path, err)
+	}
+	libs := make(map[uint64]*Library)
+	linked := make(map[uint64]map[string]bool)
+	for _, id := range m.IDs {
+		blk := id.Block
+		if blk.Hdr.Code == block.CodeLI {
+			if err := blk.ParseBody(dna); err != nil {
+				return f, fmt.Errorf("library.Resolve: parsing library at %#x of %q: %v", blk.Hdr.OldAddr, path, err)
+			}
+			libName := generic.FieldString(blk.Body, "Name")
+			lib := &Library{
+				Name:     libName,
+				Path:     packed.BlenderAbs(path, libName),
+				Indirect: generic.FieldAddr(blk.Body, "Parent") != 0,
+			}
+			libs[blk.Hdr.OldAddr] = lib
+			f.Libraries = append(f.Libraries, lib)
+			continue
+		}
+		if !id.Linked() {
+			f.ids[id.Name] = true
+			continue
+		}
+		if linked[id.Lib] == nil {
+			linked[id.Lib] = make(map[string]bool)
+		}
+		linked[id.Lib][id.Name] = true
+	}
+
+	for addr, names := range linked {
+		lib, ok := libs[addr]
+		if !ok {
+			return f, fmt.Errorf("library.Resolve: unable to locate library at %#x of %q", addr, path)
+		}
+		for name := range names {
+			lib.IDs = append(lib.IDs, name)
+		}
+		sort.Strings(lib.IDs)
+	}
+	return f, nil
+}
+
+// findCycles returns the cycles of the graph, in the order they are found by a
+// depth-first search from the files of the graph.
+func (g *Graph) findCycles() [][]string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[*File]int)
+	var stack []string
+	var cycles [][]string
+	var visit func(f *File)
+	visit = func(f *File) {
+		state[f] = visiting
+		stack = append(stack, f.Path)
+		for _, lib := range f.Libraries {
+			dep := g.files[lib.Path]
+			switch state[dep] {
+			case unvisited:
+				visit(dep)
+			case visiting:
+				for i, path := range stack {
+					if path == dep.Path {
+						cycle := append([]string(nil), stack[i:]...)
+						cycles = append(cycles, append(cycle, dep.Path))
+						break
+					}
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[f] = visited
+	}
+	for _, f := range g.Files {
+		if state[f] == unvisited {
+			visit(f)
+		}
+	}
+	return cycles
+}
@@ -0,0 +1,151 @@
+package library_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+	"github.com/mewspring/blend/file"
+	"github.com/mewspring/blend/library"
+)
+
+// writeLinking writes a copy of the v400 golden file to path, which links the
+// IDs of the given names from the library file libPath.
+func writeLinking(t *testing.T, path, libPath string, names ...string) {
+	t.Helper()
+	f, err := os.Open("../golden/v400_uncompressed.blend")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer f.Close()
+	d, err := file.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := blend.Decode(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dna, err := b.GetDNA()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lib, err := b.NewBlock(dna, block.CodeLI, "Library", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := generic.SetFieldString(generic.Field(lib.Body, "Id").Addr().Interface(), "Name", "LI"+filepath.Base(libPath)); err != nil {
+		t.Fatal(err)
+	}
+	if err := generic.SetFieldString(lib.Body, "Name", libPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.InsertBlocks(dna, b.Blocks[len(b.Blocks)-1], lib); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := b.Main(dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		id := m.Lookup(name, 0)
+		if id == nil {
+			t.Fatalf("unable to locate ID %q", name)
+		}
+		if err := id.Block.ParseBody(dna); err != nil {
+			t.Fatal(err)
+		}
+		if err := generic.SetFieldAddr(generic.Field(id.Block.Body, "Id").Addr().Interface(), "Lib", lib.Hdr.OldAddr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := blend.EncodeFile(path, b, blend.EncodeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.blend")
+	b := filepath.Join(dir, "b.blend")
+	// a.blend links MEPlane from b.blend, which links MEPlane and MESphere back
+	// from a.blend.
+	writeLinking(t, a, "//b.blend", "MEPlane")
+	writeLinking(t, b, "//a.blend", "MEPlane", "MESphere")
+
+	g, err := library.Resolve(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Root != a {
+		t.Errorf("root mismatch; expected %q, got %q", a, g.Root)
+	}
+	if len(g.Files) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(g.Files))
+	}
+	fa, fb := g.File(a), g.File(b)
+	if fa == nil || fb == nil {
+		t.Fatal("unable to locate files of graph")
+	}
+	if len(fa.Libraries) != 1 {
+		t.Fatalf("expected 1 library of %q, got %d", a, len(fa.Libraries))
+	}
+	lib := fa.Libraries[0]
+	if lib.Name != "//b.blend" || lib.Path != b {
+		t.Errorf("library mismatch; expected %q at %q, got %q at %q", "//b.blend", b, lib.Name, lib.Path)
+	}
+	if want := []string{"MEPlane"}; !reflect.DeepEqual(lib.IDs, want) {
+		t.Errorf("linked IDs mismatch; expected %q, got %q", want, lib.IDs)
+	}
+	// MEPlane is linked rather than local in both files.
+	if want := []string{"MEPlane"}; !reflect.DeepEqual(lib.MissingIDs, want) {
+		t.Errorf("missing IDs mismatch; expected %q, got %q", want, lib.MissingIDs)
+	}
+	if len(fb.Libraries) != 1 {
+		t.Fatalf("expected 1 library of %q, got %d", b, len(fb.Libraries))
+	}
+	if want := []string{"MEPlane", "MESphere"}; !reflect.DeepEqual(fb.Libraries[0].IDs, want) {
+		t.Errorf("linked IDs mismatch; expected %q, got %q", want, fb.Libraries[0].IDs)
+	}
+	if want := []string{"MEPlane"}; !reflect.DeepEqual(fb.Libraries[0].MissingIDs, want) {
+		t.Errorf("missing IDs mismatch; expected %q, got %q", want, fb.Libraries[0].MissingIDs)
+	}
+	if len(g.Cycles) != 1 {
+		t.Fatalf("expected 1 cycle, got %d", len(g.Cycles))
+	}
+	if want := []string{a, b, a}; !reflect.DeepEqual(g.Cycles[0], want) {
+		t.Errorf("cycle mismatch; expected %q, got %q", want, g.Cycles[0])
+	}
+
+	var dot strings.Builder
+	if err := g.WriteDOT(&dot); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(dot.String(), `"`+a+`" -> "`+b+`"`) {
+		t.Errorf("missing edge from %q to %q in DOT output:\n%s", a, b, dot.String())
+	}
+}
+
+func TestResolveMissing(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.blend")
+	writeLinking(t, a, "//missing.blend", "MEPlane")
+	g, err := library.Resolve(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := g.File(filepath.Join(dir, "missing.blend"))
+	if f == nil || !f.Missing {
+		t.Fatalf("missing library file not reported; got %+v", f)
+	}
+	if len(g.Cycles) != 0 {
+		t.Errorf("expected no cycles, got %q", g.Cycles)
+	}
+}
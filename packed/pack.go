@@ -0,0 +1,162 @@
+package packed
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+)
+
+// Image sources (Image.Source).
+const (
+	ImageSourceFile      = 1
+	ImageSourceSequence  = 2
+	ImageSourceMovie     = 3
+	ImageSourceGenerated = 4
+	ImageSourceViewer    = 5
+	ImageSourceTiled     = 6
+)
+
+// Pack packs data into b as the packed file of owner, which must be an ID block
+// of PathFields without a packed file. The new blocks are inserted after the
+// owner block; for images, an ImagePackedFile entry with the given path is
+// added as well.
+func Pack(b *blend.Blend, dna *block.DNA, owner *block.Block, path string, data []byte) (*File, error) {
+	if err := owner.ParseBody(dna); err != nil {
+		return nil, err
+	}
+	if _, ok := PathFields[owner.Hdr.Code]; !ok {
+		return nil, fmt.Errorf("packed.Pack: %q blocks can not hold packed files", owner.Hdr.Code)
+	}
+	if IsPacked(owner.Body) {
+		return nil, fmt.Errorf("packed.Pack: %q is already packed", generic.IDName(owner.Body))
+	}
+
+	pf, err := b.NewBlock(dna, block.CodeDATA, "PackedFile", 1)
+	if err != nil {
+		return nil, err
+	}
+	size := generic.Field(pf.Body, "Size")
+	if size.OverflowInt(int64(len(data))) {
+		return nil, fmt.Errorf("packed.Pack: %d bytes exceed the maximum packed file size", len(data))
+	}
+	size.SetInt(int64(len(data)))
+	dataBlk := b.NewDataBlock(data)
+	if err := generic.SetFieldAddr(pf.Body, "Data", dataBlk.Hdr.OldAddr); err != nil {
+		return nil, err
+	}
+	blks := []*block.Block{pf, dataBlk}
+	f := &File{Owner: owner, Path: path, Block: pf, Data: dataBlk}
+
+	// Images refer to their packed files through a list of ImagePackedFile.
+	if lb := generic.Field(owner.Body, "Packedfiles"); lb.IsValid() {
+		ipf, err := b.NewBlock(dna, block.CodeDATA, "ImagePackedFile", 1)
+		if err != nil {
+			return nil, err
+		}
+		if err := generic.SetFieldAddr(ipf.Body, "Packedfile", pf.Hdr.OldAddr); err != nil {
+			return nil, err
+		}
+		if err := generic.SetFieldString(ipf.Body, "Filepath", path); err != nil {
+			return nil, err
+		}
+		generic.Field(ipf.Body, "Tile_number").SetInt(int64(firstTileNumber(b, dna, owner)))
+		if err := b.ListAppend(dna, owner, lb.Addr().Interface(), ipf); err != nil {
+			return nil, err
+		}
+		blks = append([]*block.Block{ipf}, blks...)
+		f.Entry = ipf
+	}
+	if err := generic.SetFieldAddr(owner.Body, "Packedfile", pf.Hdr.OldAddr); err != nil {
+		return nil, err
+	}
+
+	// Blender associates DATA blocks with the preceding ID block.
+	if err := b.InsertBlocks(dna, owner, blks...); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// IsPacked reports whether the ID datablock pointed to by body holds a packed
+// file.
+func IsPacked(body any) bool {
+	if generic.FieldAddr(body, "Packedfile") != 0 {
+		return true
+	}
+	lb := generic.Field(body, "Packedfiles")
+	return lb.IsValid() && generic.PointerAddr(lb.FieldByName("First")) != 0
+}
+
+// firstTileNumber returns the tile number of the first tile of the given image,
+// or 1001 if the image has no tiles.
+func firstTileNumber(b *blend.Blend, dna *block.DNA, img *block.Block) int {
+	const defaultTile = 1001
+	lb := generic.Field(img.Body, "Tiles")
+	if !lb.IsValid() {
+		return defaultTile
+	}
+	// The tiles are DATA blocks of the image, which are only unique per owner.
+	tile, ok := lookup(b.NewResolver(), img, generic.PointerAddr(lb.FieldByName("First")))
+	if !ok || tile.ParseBody(dna) != nil {
+		return defaultTile
+	}
+	if n := generic.Field(tile.Body, "Tile_number"); n.IsValid() {
+		return int(n.Int())
+	}
+	return defaultTile
+}
+
+// AbsPath returns the absolute file system path of path, as stored in the ID
+// block owner of b. Paths with Blender's "//" prefix are relative to the
+// directory of the blend file located at blendPath, or to the directory of the
+// library file if owner is linked from a library.
+func AbsPath(b *blend.Blend, dna *block.DNA, blendPath string, owner *block.Block, path string) (string, error) {
+	base := blendPath
+	if owner != nil {
+		if err := owner.ParseBody(dna); err != nil {
+			return "", err
+		}
+		if libAddr := LibAddr(owner.Body); libAddr != 0 {
+			lib, ok := b.OldAddr[libAddr]
+			if !ok {
+				return "", fmt.Errorf("packed.AbsPath: unable to locate library at %#x", libAddr)
+			}
+			if err := lib.ParseBody(dna); err != nil {
+				return "", err
+			}
+			libPath := generic.FieldString(lib.Body, "Filepath_abs")
+			if libPath == "" {
+				libPath = generic.FieldString(lib.Body, "Name")
+			}
+			base = BlenderAbs(blendPath, libPath)
+		}
+	}
+	return BlenderAbs(base, path), nil
+}
+
+// LibAddr returns the address of the library of the ID datablock pointed to by
+// body, or zero if the ID is local to the blend file.
+func LibAddr(body any) uint64 {
+	id := generic.Field(body, "Id")
+	if !id.IsValid() {
+		return 0
+	}
+	return generic.PointerAddr(id.FieldByName("Lib"))
+}
+
+// BlenderAbs returns path made absolute in the same way as Blender's
+// BLI_path_abs; a "//" prefix denotes the directory of the blend file located
+// at base.
+func BlenderAbs(base, path string) string {
+	rel, ok := strings.CutPrefix(path, "//")
+	if !ok {
+		return filepath.Clean(filepath.FromSlash(path))
+	}
+	// Blender files saved on Windows use backslash separators.
+	rel = strings.ReplaceAll(rel, `\`, "/")
+	return filepath.Join(filepath.Dir(base), filepath.FromSlash(rel))
+}
@@ -0,0 +1,231 @@
+// Package packed provides access to the files packed into a blend file (e.g.
+// images, fonts, sounds, libraries and volumes).
+package packed
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+)
+
+// PathFields maps the codes of ID blocks which may own a packed file to the
+// name of the field holding the original file path.
+var PathFields = map[block.Code]string{
+	block.CodeIM: "Name",
+	block.CodeVF: "Name",
+	block.CodeSO: "Name",
+	block.CodeLI: "Name",
+	block.CodeVO: "Filepath",
+}
+
+// A File is a file packed into a blend file.
+type File struct {
+	// Owner is the ID block owning the packed file.
+	Owner *block.Block
+	// Path is the original file path of the packed file, as stored in the
+	// blend file (i.e. possibly relative to the blend file with a "//" prefix).
+	Path string
+	// Block is the PackedFile block.
+	Block *block.Block
+	// Data is the DATA block holding the file contents.
+	Data *block.Block
+	// Entry is the ImagePackedFile block referring to the packed file of an
+	// image; or nil for other ID types and images packed by older versions of
+	// Blender.
+	Entry *block.Block
+}
+
+// Files returns the packed files of b, in block order.
+func Files(b *blend.Blend, dna *block.DNA) ([]*File, error) {
+	var files []*File
+	// The addresses of DATA blocks are only unique among the blocks of their
+	// owner.
+	r := b.NewResolver()
+	seen := make(map[*block.Block]bool)
+	// add adds the packed file of owner at the given address, referred to from
+	// the block from.
+	add := func(owner, from *block.Block, addr uint64, path string, entry *block.Block) error {
+		if addr == 0 {
+			return nil
+		}
+		f, err := newFile(r, dna, owner, from, addr, path)
+		if err != nil {
+			return err
+		}
+		if seen[f.Block] {
+			return nil
+		}
+		seen[f.Block] = true
+		f.Entry = entry
+		files = append(files, f)
+		return nil
+	}
+
+	for _, blk := range b.Blocks {
+		pathField, ok := PathFields[blk.Hdr.Code]
+		if !ok {
+			continue
+		}
+		if err := blk.ParseBody(dna); err != nil {
+			return nil, fmt.Errorf("packed.Files: parsing %q block at %#x: %v", blk.Hdr.Code, blk.Hdr.OldAddr, err)
+		}
+		path := generic.FieldString(blk.Body, pathField)
+
+		// Images may hold one packed file per view and tile.
+		lb := generic.Field(blk.Body, "Packedfiles")
+		if lb.IsValid() {
+			visited := make(map[uint64]bool)
+			from := blk
+			for addr := generic.PointerAddr(lb.FieldByName("First")); addr != 0 && !visited[addr]; {
+				visited[addr] = true
+				ipf, ok := lookup(r, from, addr)
+				if !ok {
+					return nil, fmt.Errorf("packed.Files: unable to locate ImagePackedFile at %#x", addr)
+				}
+				if err := ipf.ParseBody(dna); err != nil {
+					return nil, fmt.Errorf("packed.Files: parsing ImagePackedFile at %#x: %v", addr, err)
+				}
+				ipfPath := generic.FieldString(ipf.Body, "Filepath")
+				if ipfPath == "" {
+					ipfPath = path
+				}
+				if err := add(blk, ipf, generic.FieldAddr(ipf.Body, "Packedfile"), ipfPath, ipf); err != nil {
+					return nil, err
+				}
+				from = ipf
+				addr = generic.FieldAddr(ipf.Body, "Next")
+			}
+		}
+
+		if err := add(blk, blk, generic.FieldAddr(blk.Body, "Packedfile"), path, nil); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// newFile returns the packed file of owner stored in the PackedFile block at
+// the given address, referred to from the block from.
+func newFile(r *blend.Resolver, dna *block.DNA, owner, from *block.Block, addr uint64, path string) (*File, error) {
+	f := &File{Owner: owner, Path: path}
+	var ok bool
+	f.Block, ok = lookup(r, from, addr)
+	if !ok {
+		return nil, fmt.Errorf("packed.Files: unable to locate PackedFile at %#x", addr)
+	}
+	if err := f.Block.ParseBody(dna); err != nil {
+		return nil, fmt.Errorf("packed.Files: parsing PackedFile at %#x: %v", addr, err)
+	}
+	dataAddr := generic.FieldAddr(f.Block.Body, "Data")
+	f.Data, ok = lookup(r, f.Block, dataAddr)
+	if !ok {
+		return nil, fmt.Errorf("packed.Files: unable to locate data of PackedFile at %#x", addr)
+	}
+	if err := f.Data.ParseBody(dna); err != nil {
+		return nil, fmt.Errorf("packed.Files: parsing data of PackedFile at %#x: %v", addr, err)
+	}
+	if _, ok := f.Data.Body.([]byte); !ok {
+		return nil, fmt.Errorf("packed.Files: unexpected data type %T of PackedFile at %#x", f.Data.Body, addr)
+	}
+	return f, nil
+}
+
+// lookup returns the block starting at the given address, referred to from the
+// block from, and reports whether such a block exists.
+func lookup(r *blend.Resolver, from *block.Block, addr uint64) (*block.Block, bool) {
+	loc, ok := r.Resolve(from, addr)
+	if !ok || loc.Offset != 0 {
+		return nil, false
+	}
+	return loc.Block, true
+}
+
+// ID returns the name of the owning ID datablock, including its two-letter code
+// prefix.
+func (f *File) ID() string {
+	return generic.IDName(f.Owner.Body)
+}
+
+// Size returns the size in bytes of the packed file.
+func (f *File) Size() int {
+	return int(generic.Field(f.Block.Body, "Size").Int())
+}
+
+// Bytes returns the contents of the packed file.
+func (f *File) Bytes() ([]byte, error) {
+	data := f.Data.Body.([]byte)
+	size := f.Size()
+	if size < 0 || size > len(data) {
+		return nil, fmt.Errorf("File.Bytes: packed file size %d of %q out of bounds (%d bytes of data)", size, f.ID(), len(data))
+	}
+	return data[:size], nil
+}
+
+// WriteTo writes the contents of the packed file to w.
+func (f *File) WriteTo(w io.Writer) (int64, error) {
+	data, err := f.Bytes()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// Extract writes the contents of the packed file to the given path, creating
+// parent directories as needed. An existing file at path is left untouched; it
+// is an error for its contents to differ from those of the packed file.
+func (f *File) Extract(path string) error {
+	data, err := f.Bytes()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0666)
+	if os.IsExist(err) {
+		prev, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(prev, data) {
+			return fmt.Errorf("File.Extract: %q already exists with different contents", path)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := out.Write(data); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// Replace replaces the contents of the packed file with data, updating the size
+// of the PackedFile structure and the header of the DATA block.
+func (f *File) Replace(data []byte) error {
+	size := generic.Field(f.Block.Body, "Size")
+	if size.OverflowInt(int64(len(data))) {
+		return fmt.Errorf("File.Replace: %d bytes exceed the maximum packed file size", len(data))
+	}
+	size.SetInt(int64(len(data)))
+	if seek := generic.Field(f.Block.Body, "Seek"); seek.IsValid() {
+		seek.SetInt(0)
+	}
+
+	// Blender pads DATA blocks to a multiple of 4 bytes.
+	body := make([]byte, (len(data)+3)&^3)
+	copy(body, data)
+	f.Data.Body = body
+	f.Data.Hdr.Size = int64(len(body))
+	f.Data.Hdr.Count = 1
+	return nil
+}
@@ -0,0 +1,247 @@
+package packed_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+	"github.com/mewspring/blend/file"
+	"github.com/mewspring/blend/packed"
+)
+
+// decode decodes the given blend file contents, and returns the blend file with
+// its DNA.
+func decode(t *testing.T, data []byte) (*blend.Blend, *block.DNA) {
+	t.Helper()
+	d, err := file.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := blend.Decode(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dna, err := b.GetDNA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, dna
+}
+
+// encode returns the encoding of b.
+func encode(t *testing.T, b *blend.Blend) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := blend.Encode(buf, b); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// packGolden returns the encoding of the v400 golden file, with data packed
+// into its image.
+func packGolden(t *testing.T, data []byte) []byte {
+	t.Helper()
+	golden, err := os.ReadFile("../golden/v400_uncompressed.blend")
+	if err != nil {
+		t.Skip(err)
+	}
+	b, dna := decode(t, golden)
+	img := imageOf(t, b)
+	if err := img.ParseBody(dna); err != nil {
+		t.Fatal(err)
+	}
+	generic.Field(img.Body, "Source").SetInt(packed.ImageSourceFile)
+	if _, err := packed.Pack(b, dna, img, "//textures/wood.png", data); err != nil {
+		t.Fatal(err)
+	}
+	return encode(t, b)
+}
+
+// imageOf returns the image block of the golden file.
+func imageOf(t *testing.T, b *blend.Blend) *block.Block {
+	t.Helper()
+	for _, blk := range b.Blocks {
+		if blk.Hdr.Code == block.CodeIM {
+			return blk
+		}
+	}
+	t.Fatal("unable to locate image of golden file")
+	return nil
+}
+
+// filesOf returns the single packed file of b.
+func filesOf(t *testing.T, b *blend.Blend, dna *block.DNA) *packed.File {
+	t.Helper()
+	files, err := packed.Files(b, dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected 1 packed file, got %d", len(files))
+	}
+	return files[0]
+}
+
+// appendShadows adds the given DATA blocks to b, owned by its last ID block.
+// The blocks may reuse the addresses of DATA blocks of other owners.
+func appendShadows(b *blend.Blend, shadows ...*block.Block) {
+	var last int
+	for i, blk := range b.Blocks {
+		if blk.Hdr.Code != block.CodeDATA && blk.Hdr.Code != block.CodeDNA1 && blk.Hdr.Code != block.CodeENDB {
+			last = i
+		}
+	}
+	blks := append([]*block.Block{}, b.Blocks[:last+1]...)
+	blks = append(blks, shadows...)
+	b.Blocks = append(blks, b.Blocks[last+1:]...)
+	for _, shadow := range shadows {
+		b.OldAddr[shadow.Hdr.OldAddr] = shadow
+	}
+}
+
+func TestFiles(t *testing.T) {
+	data := []byte("\x89PNG\r\n\x1a\nwood")
+	b, dna := decode(t, packGolden(t, data))
+	f := filesOf(t, b, dna)
+	if f.Owner != imageOf(t, b) || f.Entry == nil {
+		t.Errorf("packed file not owned by image through an ImagePackedFile")
+	}
+	if f.ID() != "IMRender Result" || f.Path != "//textures/wood.png" {
+		t.Errorf("packed file mismatch; expected %q of %q, got %q of %q", "//textures/wood.png", "IMRender Result", f.Path, f.ID())
+	}
+	if f.Size() != len(data) {
+		t.Errorf("size mismatch; expected %d, got %d", len(data), f.Size())
+	}
+	got, err := f.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("contents mismatch; expected %q, got %q", data, got)
+	}
+
+	buf := new(bytes.Buffer)
+	if n, err := f.WriteTo(buf); err != nil || n != int64(len(data)) || !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("WriteTo mismatch; expected %q, got %q (%d bytes, %v)", data, buf.Bytes(), n, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "textures", "wood.png")
+	if err := f.Extract(path); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := os.ReadFile(path); err != nil || !bytes.Equal(got, data) {
+		t.Errorf("extracted file mismatch; expected %q, got %q (%v)", data, got, err)
+	}
+
+	// Existing files are never overwritten.
+	if err := f.Extract(path); err != nil {
+		t.Errorf("unable to extract over identical file: %v", err)
+	}
+	other := []byte("other")
+	if err := os.WriteFile(path, other, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Extract(path); err == nil {
+		t.Error("expected error for existing file with different contents")
+	}
+	if got, err := os.ReadFile(path); err != nil || !bytes.Equal(got, other) {
+		t.Errorf("existing file overwritten; expected %q, got %q (%v)", other, got, err)
+	}
+}
+
+func TestFilesShadowed(t *testing.T) {
+	data := []byte("wood")
+	b, dna := decode(t, packGolden(t, data))
+	f := filesOf(t, b, dna)
+
+	// DATA block addresses are only unique per owner; shadow the packed file
+	// blocks by DATA blocks of another owner.
+	var shadows []*block.Block
+	for _, shadowed := range []*block.Block{f.Entry, f.Block, f.Data} {
+		shadow := &block.Block{Hdr: shadowed.Hdr, Body: bytes.Repeat([]byte{0xFF}, int(shadowed.Hdr.Size))}
+		shadow.Hdr.SDNAIndex = 0
+		shadows = append(shadows, shadow)
+	}
+	appendShadows(b, shadows...)
+
+	got, err := filesOf(t, b, dna).Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("contents mismatch; expected %q, got %q", data, got)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	b, dna := decode(t, packGolden(t, []byte("wood")))
+	data := []byte("replaced contents")
+	if err := filesOf(t, b, dna).Replace(data); err != nil {
+		t.Fatal(err)
+	}
+
+	// The replaced contents survive re-encoding.
+	b, dna = decode(t, encode(t, b))
+	f := filesOf(t, b, dna)
+	got, err := f.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("contents mismatch; expected %q, got %q", data, got)
+	}
+	// Blender pads DATA blocks to a multiple of 4 bytes.
+	if want := int64(len(data)+3) &^ 3; f.Data.Hdr.Size != want {
+		t.Errorf("size of DATA block mismatch; expected %d, got %d", want, f.Data.Hdr.Size)
+	}
+}
+
+func TestPackTileNumber(t *testing.T) {
+	golden, err := os.ReadFile("../golden/v400_uncompressed.blend")
+	if err != nil {
+		t.Skip(err)
+	}
+	b, dna := decode(t, golden)
+	img := imageOf(t, b)
+	if err := img.ParseBody(dna); err != nil {
+		t.Fatal(err)
+	}
+	addr := generic.PointerAddr(generic.Field(img.Body, "Tiles").FieldByName("First"))
+	var tile *block.Block
+	for _, blk := range b.Blocks {
+		if blk.Hdr.Code == block.CodeDATA && blk.Hdr.OldAddr == addr {
+			tile = blk
+		}
+	}
+	if tile == nil {
+		t.Fatal("unable to locate image tile of golden file")
+	}
+	if err := tile.ParseBody(dna); err != nil {
+		t.Fatal(err)
+	}
+	generic.Field(tile.Body, "Tile_number").SetInt(1002)
+
+	// The entry takes the tile number of the image, not of a DATA block of
+	// another owner at the same address.
+	shadow, err := b.NewBlock(dna, block.CodeDATA, "ImageTile", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shadow.Hdr.OldAddr = addr
+	generic.Field(shadow.Body, "Tile_number").SetInt(1003)
+	appendShadows(b, shadow)
+
+	generic.Field(img.Body, "Source").SetInt(packed.ImageSourceTiled)
+	f, err := packed.Pack(b, dna, img, "//textures/wood.<UDIM>.png", []byte("wood"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := generic.Field(f.Entry.Body, "Tile_number").Int(); got != 1002 {
+		t.Errorf("tile number mismatch; expected 1002, got %d", got)
+	}
+}
@@ -0,0 +1,65 @@
+package packed
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+)
+
+// UnpackDirs maps the codes of ID blocks which may own a packed file to the
+// directory, relative to the blend file, to which Blender unpacks them.
+var UnpackDirs = map[block.Code]string{
+	block.CodeIM: "textures",
+	block.CodeVF: "fonts",
+	block.CodeSO: "sounds",
+	block.CodeLI: "libraries",
+	block.CodeVO: "volumes",
+}
+
+// LocalPath returns the path, relative to the blend file, to which the packed
+// file is unpacked (e.g. "//textures/wood.png"). The file name is taken from
+// the original file path, or from the name of the owning ID if the path is
+// empty.
+func (f *File) LocalPath() string {
+	name := path.Base(strings.ReplaceAll(f.Path, `\`, "/"))
+	if name == "." || name == "/" {
+		name = strings.ReplaceAll(f.ID(), "/", "_")
+		if len(name) > 2 {
+			name = name[2:]
+		}
+	}
+	return "//" + path.Join(UnpackDirs[f.Owner.Hdr.Code], name)
+}
+
+// Unpack removes the packed file f from b, and makes its owner refer to the
+// external file at path instead. The PackedFile and DATA blocks, as well as the
+// ImagePackedFile entry of images, are removed from b. Unpack does not write
+// the contents of the packed file; use Extract for that purpose.
+func Unpack(b *blend.Blend, dna *block.DNA, f *File, path string) error {
+	owner := f.Owner
+	if err := owner.ParseBody(dna); err != nil {
+		return err
+	}
+	if err := generic.SetFieldString(owner.Body, PathFields[owner.Hdr.Code], path); err != nil {
+		return fmt.Errorf("packed.Unpack: %v", err)
+	}
+	if generic.FieldAddr(owner.Body, "Packedfile") == f.Block.Hdr.OldAddr {
+		if err := generic.SetFieldAddr(owner.Body, "Packedfile", 0); err != nil {
+			return err
+		}
+	}
+
+	blks := []*block.Block{f.Block, f.Data}
+	if f.Entry != nil {
+		if err := b.ListRemove(dna, owner, generic.Field(owner.Body, "Packedfiles").Addr().Interface(), f.Entry); err != nil {
+			return err
+		}
+		blks = append(blks, f.Entry)
+	}
+	b.RemoveBlocks(blks...)
+	return nil
+}
@@ -0,0 +1,95 @@
+package blend
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/mewspring/blend/block"
+	"go.uber.org/multierr"
+)
+
+// ParseOptions specifies optional behaviour of ParseAllContext.
+type ParseOptions struct {
+	// Workers is the number of block bodies parsed concurrently; or zero to use
+	// one worker per CPU.
+	Workers int
+	// Progress, if non-nil, is called after each block body parsed. Calls are
+	// serialized, even when parsing concurrently.
+	Progress func(Progress)
+}
+
+// ParseAll parses the bodies of all SDNA blocks of b concurrently, using the
+// given number of workers; see ParseAllContext.
+func (b *Blend) ParseAll(dna *block.DNA, workers int) error {
+	return b.ParseAllContext(context.Background(), dna, ParseOptions{Workers: workers})
+}
+
+// ParseAllContext parses the bodies of all SDNA blocks of b (i.e. blocks with a
+// non-zero SDNA index), which have not yet been parsed, using a bounded pool of
+// workers. The context is checked between blocks, and parsing stops with the
+// error of the context once it is done.
+//
+// Blocks which fail to parse do not stop the parsing of other blocks. The
+// errors are combined in block order, regardless of the order in which the
+// blocks were parsed.
+func (b *Blend) ParseAllContext(ctx context.Context, dna *block.DNA, opts ParseOptions) error {
+	var progress Progress
+	var blks []*block.Block
+	for _, blk := range b.Blocks {
+		if blk.Hdr.SDNAIndex == 0 {
+			continue
+		}
+		blks = append(blks, blk)
+		progress.TotalBytes += blk.Hdr.Size
+	}
+	progress.TotalBlocks = len(blks)
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(blks) {
+		workers = len(blks)
+	}
+
+	// errs holds the error of each block, in block order.
+	errs := make([]error, len(blks))
+	// mu serializes progress reports.
+	var mu sync.Mutex
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				blk := blks[i]
+				if err := blk.ParseBody(dna); err != nil {
+					errs[i] = fmt.Errorf("Blend.ParseAllContext: parsing %q block at %#x: %v", blk.Hdr.Code, blk.Hdr.OldAddr, err)
+				}
+				if opts.Progress != nil {
+					mu.Lock()
+					progress.Blocks++
+					progress.Bytes += blk.Hdr.Size
+					opts.Progress(progress)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	var err error
+	for i := range blks {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	if err != nil {
+		return err
+	}
+	return multierr.Combine(errs...)
+}
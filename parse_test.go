@@ -0,0 +1,73 @@
+package blend_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/mewspring/blend/block"
+)
+
+func TestParseAll(t *testing.T) {
+	for _, path := range []string{"golden/v305_uncompressed.blend", "golden/v400_uncompressed.blend"} {
+		t.Run(path, func(t *testing.T) {
+			b, dna := decodeGolden(t, path)
+			// Bodies decoded sequentially, without storing them.
+			want := make(map[*block.Block]any)
+			for _, blk := range b.Blocks {
+				if blk.Hdr.SDNAIndex == 0 {
+					continue
+				}
+				body, err := blk.Decode(dna)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want[blk] = body
+			}
+			if err := b.ParseAll(dna, 8); err != nil {
+				t.Fatal(err)
+			}
+			for blk, body := range want {
+				if !reflect.DeepEqual(blk.Body, body) {
+					t.Errorf("body of %q block at %#x mismatch", blk.Hdr.Code, blk.Hdr.OldAddr)
+				}
+			}
+		})
+	}
+}
+
+func TestParseBodyConcurrent(t *testing.T) {
+	b, dna := decodeGolden(t, "golden/v400_uncompressed.blend")
+	var blks []*block.Block
+	for _, blk := range b.Blocks {
+		if blk.Hdr.SDNAIndex != 0 && len(blks) < 16 {
+			blks = append(blks, blk)
+		}
+	}
+	// Parse the same blocks from several goroutines.
+	const n = 8
+	bodies := make([][]any, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for _, blk := range blks {
+				if err := blk.ParseBody(dna); err != nil {
+					t.Error(err)
+					return
+				}
+				bodies[i] = append(bodies[i], blk.Body)
+			}
+		}(i)
+	}
+	wg.Wait()
+	// Each block is parsed once.
+	for i := 1; i < n; i++ {
+		for j := range bodies[i] {
+			if reflect.ValueOf(bodies[i][j]).Pointer() != reflect.ValueOf(bodies[0][j]).Pointer() {
+				t.Fatalf("body of block %d parsed more than once", j)
+			}
+		}
+	}
+}
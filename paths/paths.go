@@ -0,0 +1,254 @@
+// Package paths reports and rewrites the external file paths stored in a blend
+// file (e.g. the file paths of images, libraries, fonts and sounds).
+package paths
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+	"github.com/mewspring/blend/packed"
+)
+
+// Fields maps the SDNA type names of structures holding external file paths to
+// the names of the fields holding the paths. All fields are fixed-size char
+// arrays, except for Text.Name which points to a DATA block.
+var Fields = map[string][]string{
+	"Image":           {"Name"},
+	"ImagePackedFile": {"Filepath"},
+	"Library":         {"Name"},
+	"VFont":           {"Name"},
+	"bSound":          {"Name"},
+	"MovieClip":       {"Name"},
+	"CacheFile":       {"Filepath"},
+	"Volume":          {"Filepath"},
+	"Text":            {"Name"},
+	"Strip":           {"Dir"},
+}
+
+// A Path is an external file path stored in a blend file.
+type Path struct {
+	// Owner is the ID block owning the path, or the Sequence block owning the
+	// directory of a sequencer strip; or nil if the owner is unknown.
+	Owner *block.Block
+	// Name is the name of the owner, including its two-letter code prefix.
+	Name string
+	// Block is the block holding the structure which stores the path.
+	Block *block.Block
+	// Index is the index of the structure within Block.
+	Index int
+	// Type is the SDNA type name of the structure which stores the path.
+	Type string
+	// Field is the name of the field which stores the path.
+	Field string
+	// Path is the file path, as stored in the blend file (i.e. possibly
+	// relative to the blend file with a "//" prefix).
+	Path string
+
+	// data is the DATA block holding the path, if the field points to it.
+	data *block.Block
+}
+
+// List returns the external file paths of b, in block order. Empty paths are
+// omitted.
+func List(b *blend.Blend, dna *block.DNA) ([]*Path, error) {
+	// DATA block addresses are only unique per owner.
+	r := b.NewResolver()
+	owners, err := findOwners(b, dna, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []*Path
+	for _, blk := range b.Blocks {
+		typ, fields := blockFields(dna, blk)
+		if fields == nil {
+			continue
+		}
+		if err := blk.ParseBody(dna); err != nil {
+			return nil, fmt.Errorf("paths.List: parsing %s block at %#x: %v", typ, blk.Hdr.OldAddr, err)
+		}
+		for i, body := range structs(blk.Body) {
+			for _, field := range fields {
+				p := &Path{Owner: owners[blk], Block: blk, Index: i, Type: typ, Field: field}
+				if generic.IDName(body) != "" {
+					p.Owner = blk
+				}
+				if p.Owner != nil {
+					p.Name = ownerName(p.Owner)
+				}
+				p.Path, err = p.get(r, dna, body)
+				if err != nil {
+					return nil, err
+				}
+				if p.Path != "" {
+					paths = append(paths, p)
+				}
+			}
+		}
+	}
+	return paths, nil
+}
+
+// Linked reports whether the owner of the path is an ID linked from a library.
+// The paths of linked IDs are relative to the library file.
+func (p *Path) Linked() bool {
+	return p.Owner != nil && packed.LibAddr(p.Owner.Body) != 0
+}
+
+// Set stores path in the blend file as the new value of p. An error is
+// returned if path does not fit into the char array of the field.
+func (p *Path) Set(b *blend.Blend, dna *block.DNA, path string) error {
+	bodies := structs(p.Block.Body)
+	if p.Index >= len(bodies) {
+		return fmt.Errorf("Path.Set: structure index %d out of bounds of %s block at %#x", p.Index, p.Type, p.Block.Hdr.OldAddr)
+	}
+	body := bodies[p.Index]
+	v := generic.Field(body, p.Field)
+	if !generic.IsPointer(v) {
+		if err := generic.SetFieldString(body, p.Field, path); err != nil {
+			return fmt.Errorf("Path.Set: %s.%s of %q: %v", p.Type, p.Field, p.Name, err)
+		}
+		p.Path = path
+		return nil
+	}
+
+	// The path is stored as a NUL-terminated string in a DATA block.
+	for i := 0; i < len(path); i++ {
+		if path[i] == 0 {
+			return fmt.Errorf("Path.Set: %q contains NUL byte", path)
+		}
+	}
+	data := p.data
+	if data == nil || data.Hdr.OldAddr != generic.PointerAddr(v) {
+		return fmt.Errorf("Path.Set: unable to locate %s.%s of %q at %#x", p.Type, p.Field, p.Name, generic.PointerAddr(v))
+	}
+	// Blender pads DATA blocks to a multiple of 4 bytes.
+	buf := make([]byte, (len(path)+1+3)&^3)
+	copy(buf, path)
+	data.Body = buf
+	data.Hdr.Size = int64(len(buf))
+	data.Hdr.Count = 1
+	p.Path = path
+	return nil
+}
+
+// get returns the path stored in the given structure of p.Block.
+func (p *Path) get(r *blend.Resolver, dna *block.DNA, body any) (string, error) {
+	v := generic.Field(body, p.Field)
+	if !generic.IsPointer(v) {
+		return generic.FieldString(body, p.Field), nil
+	}
+	addr := generic.PointerAddr(v)
+	if addr == 0 {
+		// Text stored in the blend file only.
+		return "", nil
+	}
+	data, ok := lookup(r, p.Block, addr)
+	if !ok {
+		return "", fmt.Errorf("paths.List: unable to locate %s.%s of %q at %#x", p.Type, p.Field, p.Name, addr)
+	}
+	if err := data.ParseBody(dna); err != nil {
+		return "", fmt.Errorf("paths.List: parsing %s.%s of %q at %#x: %v", p.Type, p.Field, p.Name, addr, err)
+	}
+	buf, ok := data.Body.([]byte)
+	if !ok {
+		return "", fmt.Errorf("paths.List: unexpected type %T of %s.%s of %q", data.Body, p.Type, p.Field, p.Name)
+	}
+	p.data = data
+	return generic.CString(buf), nil
+}
+
+// blockFields returns the SDNA type name of the structures of blk, and the
+// names of their path fields; or nil if the structures hold no paths.
+func blockFields(dna *block.DNA, blk *block.Block) (string, []string) {
+	index := int(blk.Hdr.SDNAIndex)
+	if index == 0 || index >= len(dna.Structs) {
+		return "", nil
+	}
+	typ := dna.Structs[index].Type
+	return typ, Fields[typ]
+}
+
+// structs returns pointers to the structures of the given block body; body is
+// either a pointer to a structure or a slice of such pointers.
+func structs(body any) []any {
+	v := reflect.ValueOf(body)
+	if v.Kind() != reflect.Slice {
+		return []any{body}
+	}
+	bodies := make([]any, v.Len())
+	for i := range bodies {
+		bodies[i] = v.Index(i).Interface()
+	}
+	return bodies
+}
+
+// findOwners returns a map from the non-ID blocks holding paths to the blocks
+// owning them; i.e. the image of ImagePackedFile entries and the sequence of
+// sequencer strips.
+func findOwners(b *blend.Blend, dna *block.DNA, r *blend.Resolver) (map[*block.Block]*block.Block, error) {
+	owners := make(map[*block.Block]*block.Block)
+	for _, blk := range b.Blocks {
+		if blk.Hdr.SDNAIndex == 0 || int(blk.Hdr.SDNAIndex) >= len(dna.Structs) {
+			continue
+		}
+		switch dna.Structs[blk.Hdr.SDNAIndex].Type {
+		case "Image", "Sequence":
+		default:
+			continue
+		}
+		if err := blk.ParseBody(dna); err != nil {
+			return nil, fmt.Errorf("paths.List: parsing block at %#x: %v", blk.Hdr.OldAddr, err)
+		}
+		for _, body := range structs(blk.Body) {
+			if strip, ok := lookup(r, blk, generic.FieldAddr(body, "Strip")); ok {
+				owners[strip] = blk
+			}
+			lb := generic.Field(body, "Packedfiles")
+			if !lb.IsValid() {
+				continue
+			}
+			visited := make(map[uint64]bool)
+			from := blk
+			for addr := generic.PointerAddr(lb.FieldByName("First")); addr != 0 && !visited[addr]; {
+				visited[addr] = true
+				ipf, ok := lookup(r, from, addr)
+				if !ok {
+					break
+				}
+				if err := ipf.ParseBody(dna); err != nil {
+					return nil, fmt.Errorf("paths.List: parsing ImagePackedFile at %#x: %v", addr, err)
+				}
+				owners[ipf] = blk
+				from = ipf
+				addr = generic.FieldAddr(ipf.Body, "Next")
+			}
+		}
+	}
+	return owners, nil
+}
+
+// ownerName returns the name of the given ID or Sequence block.
+func ownerName(owner *block.Block) string {
+	body := structs(owner.Body)[0]
+	if name := generic.IDName(body); name != "" {
+		return name
+	}
+	return generic.FieldString(body, "Name")
+}
+
+// lookup returns the block starting at the given address, referred to from the
+// block from, and reports whether such a block exists.
+func lookup(r *blend.Resolver, from *block.Block, addr uint64) (*block.Block, bool) {
+	if addr == 0 {
+		return nil, false
+	}
+	loc, ok := r.Resolve(from, addr)
+	if !ok || loc.Offset != 0 {
+		return nil, false
+	}
+	return loc.Block, true
+}
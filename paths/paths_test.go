@@ -0,0 +1,211 @@
+package paths_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+	"github.com/mewspring/blend/file"
+	"github.com/mewspring/blend/packed"
+	"github.com/mewspring/blend/paths"
+)
+
+// decode decodes the given blend file contents, and returns the blend file with
+// its DNA.
+func decode(t *testing.T, data []byte) (*blend.Blend, *block.DNA) {
+	t.Helper()
+	d, err := file.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := blend.Decode(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dna, err := b.GetDNA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, dna
+}
+
+func TestRewrite(t *testing.T) {
+	data, err := os.ReadFile("../golden/v400_uncompressed.blend")
+	if err != nil {
+		t.Skip(err)
+	}
+	b, dna := decode(t, data)
+
+	// Store an external file path in the image of the golden file.
+	var img *block.Block
+	for _, blk := range b.Blocks {
+		if blk.Hdr.Code == block.CodeIM {
+			img = blk
+			break
+		}
+	}
+	if img == nil {
+		t.Fatal("unable to locate image of golden file")
+	}
+	if err := img.ParseBody(dna); err != nil {
+		t.Fatal(err)
+	}
+	if err := generic.SetFieldString(img.Body, "Name", "/home/alice/textures/wood.png"); err != nil {
+		t.Fatal(err)
+	}
+
+	ps, err := paths.List(b, dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 1 || ps[0].Path != "/home/alice/textures/wood.png" || ps[0].Owner != img {
+		t.Fatalf("expected image path, got %d paths", len(ps))
+	}
+
+	mappings := []paths.Mapping{{Old: "/home/alice", New: "//.."}}
+	changes, err := paths.Rewrite(b, dna, paths.MapPrefixes(mappings))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Old != "/home/alice/textures/wood.png" {
+		t.Fatalf("expected 1 changed path, got %d", len(changes))
+	}
+
+	// The rewritten path survives re-encoding.
+	buf := new(bytes.Buffer)
+	if err := blend.Encode(buf, b); err != nil {
+		t.Fatal(err)
+	}
+	b, dna = decode(t, buf.Bytes())
+	ps, err = paths.List(b, dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "//../textures/wood.png"; len(ps) != 1 || ps[0].Path != want {
+		t.Fatalf("expected path %q after re-encoding, got %d paths", want, len(ps))
+	}
+	if ps[0].Name != "IMRender Result" {
+		t.Errorf("owner mismatch; expected %q, got %q", "IMRender Result", ps[0].Name)
+	}
+}
+
+func TestListShadowed(t *testing.T) {
+	data, err := os.ReadFile("../golden/v400_uncompressed.blend")
+	if err != nil {
+		t.Skip(err)
+	}
+	b, dna := decode(t, data)
+	var img *block.Block
+	for _, blk := range b.Blocks {
+		if blk.Hdr.Code == block.CodeIM {
+			img = blk
+			break
+		}
+	}
+	if img == nil {
+		t.Fatal("unable to locate image of golden file")
+	}
+	if err := img.ParseBody(dna); err != nil {
+		t.Fatal(err)
+	}
+	generic.Field(img.Body, "Source").SetInt(packed.ImageSourceFile)
+	f, err := packed.Pack(b, dna, img, "//textures/wood.png", []byte("wood"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := generic.SetFieldString(img.Body, "Name", "//textures/wood.png"); err != nil {
+		t.Fatal(err)
+	}
+
+	// DATA block addresses are only unique per owner; shadow the
+	// ImagePackedFile entry by a DATA block of another owner.
+	shadow, err := b.NewBlock(dna, block.CodeDATA, "ImagePackedFile", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shadow.Hdr.OldAddr = f.Entry.Hdr.OldAddr
+	if err := generic.SetFieldString(shadow.Body, "Filepath", "//shadow.png"); err != nil {
+		t.Fatal(err)
+	}
+	for i := len(b.Blocks) - 1; i >= 0; i-- {
+		if blk := b.Blocks[i]; blk.Hdr.Code == block.CodeOB {
+			b.Blocks = append(b.Blocks[:i+1], append([]*block.Block{shadow}, b.Blocks[i+1:]...)...)
+			b.OldAddr[shadow.Hdr.OldAddr] = shadow
+			break
+		}
+	}
+
+	ps, err := paths.List(b, dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	owners := make(map[string]*block.Block)
+	for _, p := range ps {
+		owners[p.Path] = p.Owner
+	}
+	if owner, ok := owners["//textures/wood.png"]; !ok || owner != img {
+		t.Errorf("path of image not owned by image")
+	}
+	if owner := owners["//shadow.png"]; owner != nil {
+		t.Errorf("shadowing DATA block owned by %q block at %#x", owner.Hdr.Code, owner.Hdr.OldAddr)
+	}
+}
+
+func TestMapPrefixes(t *testing.T) {
+	fn := paths.MapPrefixes([]paths.Mapping{
+		{Old: "/a/b", New: "/x"},
+		{Old: `C:\`, New: "/c/"},
+	})
+	golden := []struct {
+		in, want string
+	}{
+		{in: "/a/b/c.png", want: "/x/c.png"},
+		{in: "/a/b", want: "/x"},
+		{in: "/a/bc.png", want: "/a/bc.png"},
+		{in: `C:\tex\wood.png`, want: `/c/tex\wood.png`},
+		{in: "//tex/wood.png", want: "//tex/wood.png"},
+	}
+	for _, g := range golden {
+		if got := fn(g.in); got != g.want {
+			t.Errorf("%q: expected %q, got %q", g.in, g.want, got)
+		}
+	}
+}
+
+func TestRelativeAbsolute(t *testing.T) {
+	rel := paths.Relative("/projects/scene.blend")
+	abs := paths.Absolute("/projects/scene.blend")
+	golden := []struct {
+		abs, rel string
+	}{
+		{abs: "/projects/textures/wood.png", rel: "//textures/wood.png"},
+		{abs: "/assets/wood.png", rel: "//../assets/wood.png"},
+	}
+	for _, g := range golden {
+		if got := rel(g.abs); got != g.rel {
+			t.Errorf("Relative(%q): expected %q, got %q", g.abs, g.rel, got)
+		}
+		if got := abs(g.rel); got != g.abs {
+			t.Errorf("Absolute(%q): expected %q, got %q", g.rel, g.abs, got)
+		}
+	}
+}
+
+func TestRebase(t *testing.T) {
+	rebase := paths.Rebase("/projects/scene.blend", "/projects/out/scene.blend")
+	golden := []struct {
+		in, want string
+	}{
+		{in: "//textures/wood.png", want: "//../textures/wood.png"},
+		{in: "//out/wood.png", want: "//wood.png"},
+		{in: "/assets/wood.png", want: "/assets/wood.png"},
+	}
+	for _, g := range golden {
+		if got := rebase(g.in); got != g.want {
+			t.Errorf("%q: expected %q, got %q", g.in, g.want, got)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package paths
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/packed"
+)
+
+// A Change records the rewrite of an external file path.
+type Change struct {
+	*Path
+	// Old is the path before the rewrite.
+	Old string
+}
+
+// Rewrite rewrites the external file paths of b by replacing each path p with
+// fn(p). The paths of IDs linked from libraries are left untouched, as they
+// are relative to the library file. The changed paths are returned in block
+// order. If a new path does not fit into its field, an error is returned and
+// the remaining paths are left untouched.
+func Rewrite(b *blend.Blend, dna *block.DNA, fn func(path string) string) ([]*Change, error) {
+	paths, err := List(b, dna)
+	if err != nil {
+		return nil, err
+	}
+	var changes []*Change
+	for _, p := range paths {
+		if p.Linked() {
+			continue
+		}
+		old := p.Path
+		path := fn(old)
+		if path == old {
+			continue
+		}
+		if err := p.Set(b, dna, path); err != nil {
+			return changes, err
+		}
+		changes = append(changes, &Change{Path: p, Old: old})
+	}
+	return changes, nil
+}
+
+// A Mapping maps paths with the prefix Old to paths with the prefix New.
+type Mapping struct {
+	Old, New string
+}
+
+// ParseMapping parses a mapping of the form "OLD=NEW".
+func ParseMapping(s string) (Mapping, error) {
+	old, new, ok := strings.Cut(s, "=")
+	if !ok || old == "" {
+		return Mapping{}, fmt.Errorf("paths.ParseMapping: invalid mapping %q; expected OLD=NEW", s)
+	}
+	return Mapping{Old: old, New: new}, nil
+}
+
+// MapPrefixes returns a rewrite function replacing the prefix of paths by the
+// first matching mapping. Prefixes only match at path element boundaries (e.g.
+// "/a/b" matches "/a/b/c.png" but not "/a/bc.png").
+func MapPrefixes(mappings []Mapping) func(path string) string {
+	return func(path string) string {
+		for _, m := range mappings {
+			rest, ok := strings.CutPrefix(path, m.Old)
+			if !ok {
+				continue
+			}
+			if rest == "" || isSep(rest[0]) || isSep(m.Old[len(m.Old)-1]) {
+				return m.New + rest
+			}
+		}
+		return path
+	}
+}
+
+// Relative returns a rewrite function making absolute paths relative to the
+// directory of the blend file located at blendPath, using Blender's "//"
+// prefix. Paths which are already relative, or which can not be made relative
+// (e.g. on another drive), are left untouched.
+func Relative(blendPath string) func(path string) string {
+	dir := filepath.Dir(blendPath)
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	return func(path string) string {
+		if strings.HasPrefix(path, "//") || !filepath.IsAbs(path) {
+			return path
+		}
+		rel, err := filepath.Rel(dir, filepath.Clean(path))
+		if err != nil {
+			return path
+		}
+		return "//" + rel
+	}
+}
+
+// Absolute returns a rewrite function making paths with Blender's "//" prefix
+// absolute, relative to the directory of the blend file located at blendPath.
+func Absolute(blendPath string) func(path string) string {
+	if abs, err := filepath.Abs(blendPath); err == nil {
+		blendPath = abs
+	}
+	return func(path string) string {
+		if !strings.HasPrefix(path, "//") {
+			return path
+		}
+		return packed.BlenderAbs(blendPath, path)
+	}
+}
+
+// Rebase returns a rewrite function making paths with Blender's "//" prefix,
+// relative to the directory of the blend file located at oldPath, relative to
+// the directory of the blend file located at newPath instead. Other paths are
+// left untouched.
+func Rebase(oldPath, newPath string) func(path string) string {
+	abs, rel := Absolute(oldPath), Relative(newPath)
+	return func(path string) string {
+		if !strings.HasPrefix(path, "//") {
+			return path
+		}
+		return rel(abs(path))
+	}
+}
+
+// isSep reports whether c is a path separator of Blender paths; Blender files
+// saved on Windows use backslash separators.
+func isSep(c byte) bool {
+	return c == '/' || c == '\\'
+}
@@ -0,0 +1,302 @@
+package blend
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+)
+
+// An InteriorPointer is a pointer into the middle of a block, as reported by
+// Remap.
+type InteriorPointer struct {
+	// From is the block storing the pointer.
+	From *block.Block
+	// To is the block pointed into.
+	To *block.Block
+	// Offset is the offset in bytes of the pointer into To.
+	Offset uint64
+}
+
+// Remap changes the memory addresses of the blocks of b, as given by addrs
+// from old to new memory address, and rewrites the pointers referring to the
+// blocks accordingly. As DATA block addresses are only unique per owner (see
+// addrSpace), every block with an old address of addrs is remapped; use
+// RemapBlocks to remap individual blocks.
+func (b *Blend) Remap(dna *block.DNA, addrs map[uint64]uint64) ([]InteriorPointer, error) {
+	blks := make(map[*block.Block]uint64)
+	for _, blk := range b.Blocks {
+		if addr, ok := addrs[blk.Hdr.OldAddr]; ok {
+			blks[blk] = addr
+		}
+	}
+	return b.RemapBlocks(dna, blks)
+}
+
+// RemapBlocks changes the memory addresses of the blocks of b to the addresses
+// given by addrs, and rewrites the pointers referring to the blocks
+// accordingly. Pointers into the middle of remapped blocks keep their offset,
+// and are returned for the caller to review.
+//
+// Pointers are rewritten in the BlockPointer fields of parsed bodies, and in
+// raw DATA blocks holding pointer arrays (e.g. Mesh.mat). Unparsed bodies are
+// parsed first; bodies which fail to parse, or whose Go structure definition
+// does not match the DNA, are rewritten based on the DNA and replaced by their
+// raw bytes. Pointers stored in raw DATA blocks of unknown layout (e.g. void *
+// data) are left untouched. An error is returned for parsed bodies which do not
+// match the DNA, as rewriting their raw bytes would discard modifications of
+// the parsed body.
+//
+// The new bodies of every block are computed before any block of b is updated;
+// if an error is returned, b is left untouched.
+func (b *Blend) RemapBlocks(dna *block.DNA, addrs map[*block.Block]uint64) ([]InteriorPointer, error) {
+	as := newAddrSpace(b.Blocks)
+	if err := checkRemap("Blend.RemapBlocks", as, addrs); err != nil {
+		return nil, err
+	}
+	r := &remapper{
+		b:        b,
+		dna:      dna,
+		as:       as,
+		addrs:    addrs,
+		arrays:   make(map[*block.Block]bool),
+		pointers: make(map[reflect.Type]bool),
+		bodies:   make(map[*block.Block]any),
+	}
+	r.conv = newConverter("Blend.RemapBlocks", b, dna, b.Hdr.Order, b.Hdr.PtrSize)
+	r.conv.remap = r.addr
+	for _, blk := range b.Blocks {
+		if blk.Hdr.SDNAIndex == 0 {
+			continue
+		}
+		if err := r.remapBlock(blk); err != nil {
+			return nil, err
+		}
+	}
+	for blk, size := range r.conv.hints {
+		if size == ptrArray {
+			r.arrays[blk] = true
+		}
+	}
+	// Rewrite pointer arrays in block order, for deterministic reports.
+	for _, blk := range b.Blocks {
+		if !r.arrays[blk] {
+			continue
+		}
+		if err := r.remapArray(blk); err != nil {
+			return nil, err
+		}
+	}
+
+	// Commit the rewritten pointers and bodies.
+	for _, u := range r.updates {
+		u.v.SetUint(u.addr)
+	}
+	for blk, body := range r.bodies {
+		blk.Body = body
+	}
+	for blk := range addrs {
+		b.unregister(blk)
+	}
+	for blk, addr := range addrs {
+		blk.Hdr.OldAddr = addr
+		b.register(blk)
+	}
+	return r.interior, nil
+}
+
+// checkRemap checks that the new addresses of the remapped blocks are unique
+// among the blocks of their owners and the non-DATA blocks. Errors are reported
+// as errors of the operation op.
+func checkRemap(op string, as *addrSpace, addrs map[*block.Block]uint64) error {
+	// check checks the final addresses of the blocks of the scope sc. Blocks
+	// which are not remapped keep their address, and are thus added first.
+	checked := make(map[*block.AddrIndex]bool)
+	check := func(sc *block.AddrIndex) error {
+		if sc == nil || checked[sc] {
+			return nil
+		}
+		checked[sc] = true
+		final := make(map[uint64]*block.Block)
+		var moved []*block.Block
+		for _, blk := range sc.Blocks() {
+			if _, ok := addrs[blk]; ok {
+				moved = append(moved, blk)
+				continue
+			}
+			final[blk.Hdr.OldAddr] = blk
+		}
+		for _, blk := range moved {
+			addr := addrs[blk]
+			if other, ok := final[addr]; ok {
+				return fmt.Errorf("%s: address %#x of %q block at %#x already in use by %q block", op, addr, blk.Hdr.Code, blk.Hdr.OldAddr, other.Hdr.Code)
+			}
+			final[addr] = blk
+		}
+		return nil
+	}
+	for blk, addr := range addrs {
+		if addr == 0 {
+			return fmt.Errorf("%s: zero address for %q block at %#x", op, blk.Hdr.Code, blk.Hdr.OldAddr)
+		}
+	}
+	for blk := range addrs {
+		if err := check(as.owners[blk]); err != nil {
+			return err
+		}
+		if blk.Hdr.Code != block.CodeDATA {
+			if err := check(as.global); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// remapper rewrites the pointers to remapped blocks. The rewritten pointers and
+// bodies are recorded in updates and bodies, and committed to the blocks once
+// every block has been rewritten.
+type remapper struct {
+	b   *Blend
+	dna *block.DNA
+	as  *addrSpace
+	// addrs maps from remapped block to its new address.
+	addrs map[*block.Block]uint64
+	// conv rewrites the pointers of raw bodies based on the DNA.
+	conv *converter
+	// arrays holds the raw DATA blocks which store arrays of pointers.
+	arrays map[*block.Block]bool
+	// pointers caches whether values of a type contain BlockPointers.
+	pointers map[reflect.Type]bool
+	// interior holds the pointers into the middle of remapped blocks.
+	interior []InteriorPointer
+	// updates holds the BlockPointer fields to rewrite.
+	updates []pointerUpdate
+	// bodies maps from block to its new body.
+	bodies map[*block.Block]any
+}
+
+// A pointerUpdate records the new address of a BlockPointer field.
+type pointerUpdate struct {
+	// v is the address field of the BlockPointer.
+	v reflect.Value
+	// addr is the new address.
+	addr uint64
+}
+
+// addr returns the new address of a pointer with address addr stored in from.
+func (r *remapper) addr(from *block.Block, addr uint64) uint64 {
+	if addr == 0 {
+		return 0
+	}
+	loc, ok := r.as.resolve(from, addr)
+	if !ok {
+		return addr
+	}
+	to := loc.Block
+	base, ok := r.addrs[to]
+	if !ok {
+		return addr
+	}
+	offset := uint64(loc.Offset)
+	if offset != 0 {
+		r.interior = append(r.interior, InteriorPointer{From: from, To: to, Offset: offset})
+	}
+	return base + offset
+}
+
+// remapBlock rewrites the pointers stored in the SDNA block blk.
+func (r *remapper) remapBlock(blk *block.Block) error {
+	body := blk.Body
+	if body == nil {
+		var err error
+		if body, err = blk.Decode(r.dna); err != nil {
+			return r.remapRaw(blk)
+		}
+		if int64(generic.EncodedSize(body, r.b.Hdr.PtrSize)) != blk.Hdr.Size {
+			// The Go structure definition does not match the DNA.
+			return r.remapRaw(blk)
+		}
+		r.bodies[blk] = body
+	}
+	if _, ok := body.([]byte); ok {
+		return r.remapRaw(blk)
+	}
+	if int64(generic.EncodedSize(body, r.b.Hdr.PtrSize)) != blk.Hdr.Size {
+		return fmt.Errorf("Blend.RemapBlocks: parsed body %T of %q block at %#x does not match the DNA (%d bytes)", body, blk.Hdr.Code, blk.Hdr.OldAddr, blk.Hdr.Size)
+	}
+	r.walk(blk, reflect.ValueOf(body))
+	return nil
+}
+
+// remapRaw rewrites the pointers stored in the raw body of the SDNA block blk
+// based on the DNA, and records the rewritten raw body as the new body of blk.
+func (r *remapper) remapRaw(blk *block.Block) error {
+	conv, err := r.conv.convertBlock(blk)
+	if err != nil {
+		return err
+	}
+	r.bodies[blk] = conv.Body
+	return nil
+}
+
+// remapArray rewrites the pointers stored in the raw DATA block blk holding an
+// array of pointers, and records the rewritten copy as the new body of blk.
+func (r *remapper) remapArray(blk *block.Block) error {
+	var buf []byte
+	if body, ok := blk.Body.([]byte); ok {
+		buf = append([]byte(nil), body...)
+	} else {
+		var err error
+		if buf, err = blk.RawBody(); err != nil {
+			return err
+		}
+	}
+	order, ptrSize := r.b.Hdr.Order, r.b.Hdr.PtrSize
+	for off := 0; off+ptrSize <= len(buf); off += ptrSize {
+		if ptrSize == 4 {
+			order.PutUint32(buf[off:], uint32(r.addr(blk, uint64(order.Uint32(buf[off:])))))
+		} else {
+			order.PutUint64(buf[off:], r.addr(blk, order.Uint64(buf[off:])))
+		}
+	}
+	r.bodies[blk] = buf
+	return nil
+}
+
+// walk records the rewritten BlockPointers of v, stored in from.
+func (r *remapper) walk(from *block.Block, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			r.walk(from, v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		if !hasPointers(r.pointers, v.Type().Elem()) {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			r.walk(from, v.Index(i))
+		}
+	case reflect.Struct:
+		if generic.IsPointer(v) {
+			addr := v.Field(0).Uint()
+			if generic.IsPointerArray(v.Type()) && addr != 0 {
+				if to := r.as.lookup(from, addr, true); to != nil && to.Hdr.Code == block.CodeDATA && to.Hdr.SDNAIndex == 0 {
+					r.arrays[to] = true
+				}
+			}
+			if to := r.addr(from, addr); to != addr {
+				r.updates = append(r.updates, pointerUpdate{v: v.Field(0), addr: to})
+			}
+			return
+		}
+		if !hasPointers(r.pointers, v.Type()) {
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			r.walk(from, v.Field(i))
+		}
+	}
+}
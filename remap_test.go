@@ -0,0 +1,125 @@
+package blend_test
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+	v400 "github.com/mewspring/blend/block/v400"
+)
+
+func TestRemapBlocks(t *testing.T) {
+	a, _ := decodeGolden(t, "golden/v400_uncompressed.blend")
+	b, dna := decodeGolden(t, "golden/v400_uncompressed.blend")
+
+	// Move every block to a new address, in random order.
+	addrs := make(map[*block.Block]uint64)
+	next := uint64(0x6f0000000000)
+	for _, i := range rand.New(rand.NewSource(1)).Perm(len(b.Blocks)) {
+		blk := b.Blocks[i]
+		addrs[blk] = next
+		next += uint64(blk.Hdr.Size+64) &^ 7
+	}
+	if _, err := b.RemapBlocks(dna, addrs); err != nil {
+		t.Fatal(err)
+	}
+	for blk, addr := range addrs {
+		if blk.Hdr.OldAddr != addr || b.OldAddr[addr] != blk && blk.Hdr.Code != block.CodeDATA {
+			t.Fatalf("%q block not remapped to %#x", blk.Hdr.Code, addr)
+		}
+	}
+
+	// Only the addresses differ from the original file.
+	if !bytes.Equal(encodeDeterministic(t, b), encodeDeterministic(t, a)) {
+		t.Fatal("deterministic encodings of original and remapped file differ")
+	}
+}
+
+func TestRemap(t *testing.T) {
+	b, dna := decodeGolden(t, "golden/v400_uncompressed.blend")
+	m, err := b.Main(dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mesh := m.Lookup("MESphere", 0)
+	if mesh == nil {
+		t.Fatal("unable to locate mesh \"MESphere\"")
+	}
+	addr := b.NewAddr(mesh.Block.Hdr.Size)
+	if _, err := b.Remap(dna, map[uint64]uint64{mesh.Block.Hdr.OldAddr: addr}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The object refers to the remapped mesh after re-encoding.
+	b, dna = reencode(t, b)
+	if got := b.OldAddr[addr]; got == nil || got.Hdr.Code != block.CodeME {
+		t.Fatalf("unable to locate remapped mesh at %#x", addr)
+	}
+	m, err = b.Main(dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sphere := m.Lookup("OBSphere", 0)
+	if sphere == nil {
+		t.Fatal("unable to locate object \"OBSphere\"")
+	}
+	if err := sphere.Block.ParseBody(dna); err != nil {
+		t.Fatal(err)
+	}
+	if got := generic.FieldAddr(sphere.Block.Body, "Data"); got != addr {
+		t.Errorf("data of %q mismatch; expected %#x, got %#x", sphere.Name, addr, got)
+	}
+}
+
+func TestRemapError(t *testing.T) {
+	b, dna := decodeGolden(t, "golden/v400_uncompressed.blend")
+	want := encode(t, b)
+	m, err := b.Main(dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cam, sphere := m.Lookup("OBCamera", 0), m.Lookup("OBSphere", 0)
+	if cam == nil || sphere == nil {
+		t.Fatal("unable to locate objects of golden file")
+	}
+
+	golden := []struct {
+		addrs map[*block.Block]uint64
+		want  string
+	}{
+		{addrs: map[*block.Block]uint64{cam.Block: 0}, want: "zero address"},
+		{addrs: map[*block.Block]uint64{cam.Block: sphere.Block.Hdr.OldAddr}, want: "already in use"},
+		{addrs: map[*block.Block]uint64{sphere.Block: cam.Block.Hdr.OldAddr}, want: "already in use"},
+	}
+	for _, g := range golden {
+		if _, err := b.RemapBlocks(dna, g.addrs); err == nil || !strings.Contains(err.Error(), g.want) {
+			t.Errorf("expected error containing %q, got %v", g.want, err)
+		}
+		if !bytes.Equal(encode(t, b), want) {
+			t.Fatal("blend file modified by failed remap")
+		}
+	}
+
+	// Parsed bodies which do not match the DNA are rejected before any block
+	// is modified.
+	var obj *block.Block
+	for _, id := range m.IDs {
+		if id.Code() == "OB" && id != sphere && id.Block.Body == nil {
+			obj = id.Block
+		}
+	}
+	sphere.Block.Body = &v400.LinkData{}
+	old := cam.Block.Hdr.OldAddr
+	if _, err := b.RemapBlocks(dna, map[*block.Block]uint64{cam.Block: b.NewAddr(cam.Block.Hdr.Size)}); err == nil || !strings.Contains(err.Error(), "does not match the DNA") {
+		t.Errorf("expected error for mismatched body, got %v", err)
+	}
+	if cam.Block.Hdr.OldAddr != old || b.OldAddr[old] != cam.Block {
+		t.Error("address of block changed by failed remap")
+	}
+	if obj != nil && obj.Body != nil {
+		t.Errorf("body %T of %q block stored by failed remap", obj.Body, obj.Hdr.Code)
+	}
+}
@@ -0,0 +1,34 @@
+package blend
+
+import (
+	"github.com/mewspring/blend/block"
+)
+
+// A Resolver resolves the memory addresses of pointers stored in the blocks of
+// a blend file to locations within blocks, including pointers into the middle
+// of blocks (e.g. to a specific CustomDataLayer of an array block). Pointers
+// are resolved per owner, as by Blend.GC and Blend.RemapBlocks; the addresses of
+// DATA blocks are only unique among the blocks of their owner (i.e. the
+// preceding non-DATA block).
+//
+// A Resolver is not updated when blocks are added, removed or remapped, and is
+// safe for concurrent use.
+type Resolver struct {
+	as *addrSpace
+}
+
+// NewResolver returns a resolver of the pointers stored in the blocks of b.
+func (b *Blend) NewResolver() *Resolver {
+	return &Resolver{as: newAddrSpace(b.Blocks)}
+}
+
+// Resolve returns the location referred to by a pointer with address addr
+// stored in the body of from, and reports whether such a location exists. The
+// block from may be nil for pointers not stored in a block, which are resolved
+// among the non-DATA blocks.
+func (r *Resolver) Resolve(from *block.Block, addr uint64) (block.Location, bool) {
+	if addr == 0 {
+		return block.Location{}, false
+	}
+	return r.as.resolve(from, addr)
+}
@@ -0,0 +1,381 @@
+package scene
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+)
+
+// Collection flags (Collection.Flag).
+const (
+	CollectionHideViewport = 1 << 0
+	CollectionHideSelect   = 1 << 1
+	CollectionHideRender   = 1 << 3
+	CollectionIsMaster     = 1 << 5
+)
+
+// Layer collection flags (LayerCollection.Flag).
+const (
+	LayerCollectionExclude      = 1 << 4
+	LayerCollectionHoldout      = 1 << 5
+	LayerCollectionIndirectOnly = 1 << 6
+	LayerCollectionHide         = 1 << 7
+)
+
+// View layer flags (ViewLayer.Flag).
+const (
+	ViewLayerRender    = 1 << 0
+	ViewLayerFreestyle = 1 << 2
+)
+
+// Object visibility flags (Object.Restrictflag, visibility_flag in Blender).
+const (
+	ObjectHideViewport = 1 << 0
+	ObjectHideSelect   = 1 << 1
+	ObjectHideRender   = 1 << 2
+)
+
+// A Scene is a scene datablock with its collection hierarchy and view layers.
+type Scene struct {
+	// Scene block.
+	Block *block.Block
+	// Scene name, without the "SC" code prefix.
+	Name string
+	// Master collection of the scene.
+	Master *Collection
+	// View layers of the scene, in list order.
+	ViewLayers []*ViewLayer
+}
+
+// A Collection is a collection of objects and child collections.
+type Collection struct {
+	// Collection block; either a GR block or the DATA block of a scene master
+	// collection.
+	Block *block.Block
+	// Collection name, without the "GR" code prefix.
+	Name string
+	// Collection flags.
+	Flag int
+	// Objects directly contained in the collection.
+	Objects []*Object
+	// Child collections.
+	Children []*Collection
+}
+
+// An Object is an object contained in a collection.
+type Object struct {
+	// Object block.
+	Block *block.Block
+	// Object name, without the "OB" code prefix.
+	Name string
+	// Visibility flags.
+	Visibility int
+}
+
+// A ViewLayer is a view layer of a scene.
+type ViewLayer struct {
+	// View layer name.
+	Name string
+	// View layer flags.
+	Flag int
+	// Layer collections of the view layer; the first corresponds to the master
+	// collection of the scene.
+	Collections []*LayerCollection
+}
+
+// A LayerCollection holds the view layer specific settings of a collection.
+type LayerCollection struct {
+	// Collection of the layer collection.
+	Collection *Collection
+	// Layer collection flags.
+	Flag int
+	// Child layer collections.
+	Children []*LayerCollection
+}
+
+// Scenes returns the scenes of b, including their collection hierarchies and
+// view layers.
+func Scenes(b *blend.Blend, dna *block.DNA) ([]*Scene, error) {
+	t := &collections{
+		dna:         dna,
+		r:           b.NewResolver(),
+		collections: make(map[*block.Block]*Collection),
+		objects:     make(map[*block.Block]*Object),
+	}
+	var scenes []*Scene
+	for _, blk := range b.Blocks {
+		if blk.Hdr.Code != block.CodeSC {
+			continue
+		}
+		if err := blk.ParseBody(dna); err != nil {
+			return nil, fmt.Errorf("scene.Scenes: parsing scene at %#x: %v", blk.Hdr.OldAddr, err)
+		}
+		sc := &Scene{Block: blk, Name: trimCode(generic.IDName(blk.Body))}
+		var err error
+		if addr := generic.FieldAddr(blk.Body, "Master_collection"); addr != 0 {
+			sc.Master, err = t.collection(blk, addr)
+			if err != nil {
+				return nil, err
+			}
+		}
+		layers, err := t.list(blk, generic.Field(blk.Body, "View_layers"))
+		if err != nil {
+			return nil, err
+		}
+		for _, layer := range layers {
+			vl := &ViewLayer{
+				Name: generic.FieldString(layer.Body, "Name"),
+				Flag: intField(layer.Body, "Flag"),
+			}
+			vl.Collections, err = t.layerCollections(layer, generic.Field(layer.Body, "Layer_collections"))
+			if err != nil {
+				return nil, err
+			}
+			sc.ViewLayers = append(sc.ViewLayers, vl)
+		}
+		scenes = append(scenes, sc)
+	}
+	return scenes, nil
+}
+
+// Excluded reports whether the collection is excluded from the view layer.
+func (lc *LayerCollection) Excluded() bool {
+	return lc.Flag&LayerCollectionExclude != 0
+}
+
+// Hidden reports whether the collection is hidden in the viewport of the view
+// layer.
+func (lc *LayerCollection) Hidden() bool {
+	return lc.Flag&LayerCollectionHide != 0
+}
+
+// Holdout reports whether the collection is a holdout in the view layer.
+func (lc *LayerCollection) Holdout() bool {
+	return lc.Flag&LayerCollectionHoldout != 0
+}
+
+// IndirectOnly reports whether the collection only contributes indirectly
+// (e.g. shadows and reflections) to the view layer.
+func (lc *LayerCollection) IndirectOnly() bool {
+	return lc.Flag&LayerCollectionIndirectOnly != 0
+}
+
+// Renders reports whether the view layer is used for rendering.
+func (vl *ViewLayer) Renders() bool {
+	return vl.Flag&ViewLayerRender != 0
+}
+
+// Walk calls fn for every layer collection of the view layer in depth-first
+// order, together with the enabled state inherited from its ancestors. A layer
+// collection is enabled if neither it nor any of its ancestors is excluded.
+// Walk does not descend into a layer collection if fn returns false.
+func (vl *ViewLayer) Walk(fn func(lc *LayerCollection, enabled bool) bool) {
+	var walk func(lc *LayerCollection, enabled bool)
+	walk = func(lc *LayerCollection, enabled bool) {
+		enabled = enabled && !lc.Excluded()
+		if !fn(lc, enabled) {
+			return
+		}
+		for _, child := range lc.Children {
+			walk(child, enabled)
+		}
+	}
+	for _, lc := range vl.Collections {
+		walk(lc, true)
+	}
+}
+
+// RenderObjects returns the objects rendered by the view layer, in traversal
+// order and without duplicates. An object is rendered if it is contained in an
+// enabled collection which is not hidden for rendering, and if the object
+// itself is not hidden for rendering.
+func (vl *ViewLayer) RenderObjects() []*Object {
+	var objects []*Object
+	seen := make(map[*Object]bool)
+	var visit func(lc *LayerCollection, enabled bool) bool
+	visit = func(lc *LayerCollection, enabled bool) bool {
+		c := lc.Collection
+		if !enabled || c == nil || c.Flag&CollectionHideRender != 0 {
+			return false
+		}
+		for _, ob := range c.Objects {
+			if seen[ob] || ob.Visibility&ObjectHideRender != 0 {
+				continue
+			}
+			seen[ob] = true
+			objects = append(objects, ob)
+		}
+		return true
+	}
+	vl.Walk(visit)
+	return objects
+}
+
+// collections keeps track of the collections and objects resolved so far.
+type collections struct {
+	dna *block.DNA
+	// r resolves pointers among the blocks of their owner; e.g. the master
+	// collection of a scene is a DATA block of the scene.
+	r           *blend.Resolver
+	collections map[*block.Block]*Collection
+	objects     map[*block.Block]*Object
+}
+
+// collection returns the collection at the given address, referred to from the
+// block from.
+func (t *collections) collection(from *block.Block, addr uint64) (*Collection, error) {
+	blk, err := t.block(from, addr)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := t.collections[blk]; ok {
+		return c, nil
+	}
+	c := &Collection{
+		Block: blk,
+		Name:  trimCode(generic.IDName(blk.Body)),
+		Flag:  intField(blk.Body, "Flag"),
+	}
+	// Register before resolving children to terminate on cycles.
+	t.collections[blk] = c
+
+	cobs, err := t.list(blk, generic.Field(blk.Body, "Gobject"))
+	if err != nil {
+		return nil, err
+	}
+	for _, cob := range cobs {
+		ob, err := t.object(cob, generic.FieldAddr(cob.Body, "Ob"))
+		if err != nil {
+			return nil, err
+		}
+		if ob != nil {
+			c.Objects = append(c.Objects, ob)
+		}
+	}
+
+	children, err := t.list(blk, generic.Field(blk.Body, "Children"))
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range children {
+		addr := generic.FieldAddr(child.Body, "Collection")
+		if addr == 0 {
+			continue
+		}
+		cc, err := t.collection(child, addr)
+		if err != nil {
+			return nil, err
+		}
+		c.Children = append(c.Children, cc)
+	}
+	return c, nil
+}
+
+// object returns the object at the given address, referred to from the block
+// from; or nil if addr is zero.
+func (t *collections) object(from *block.Block, addr uint64) (*Object, error) {
+	if addr == 0 {
+		return nil, nil
+	}
+	blk, err := t.block(from, addr)
+	if err != nil {
+		return nil, err
+	}
+	if ob, ok := t.objects[blk]; ok {
+		return ob, nil
+	}
+	ob := &Object{
+		Block:      blk,
+		Name:       trimCode(generic.IDName(blk.Body)),
+		Visibility: intField(blk.Body, "Restrictflag"),
+	}
+	t.objects[blk] = ob
+	return ob, nil
+}
+
+// layerCollections returns the layer collections of the given ListBase, stored
+// in the block from.
+func (t *collections) layerCollections(from *block.Block, listBase reflect.Value) ([]*LayerCollection, error) {
+	blks, err := t.list(from, listBase)
+	if err != nil {
+		return nil, err
+	}
+	var lcs []*LayerCollection
+	for _, blk := range blks {
+		lc := &LayerCollection{Flag: intField(blk.Body, "Flag")}
+		if addr := generic.FieldAddr(blk.Body, "Collection"); addr != 0 {
+			lc.Collection, err = t.collection(blk, addr)
+			if err != nil {
+				return nil, err
+			}
+		}
+		lc.Children, err = t.layerCollections(blk, generic.Field(blk.Body, "Layer_collections"))
+		if err != nil {
+			return nil, err
+		}
+		lcs = append(lcs, lc)
+	}
+	return lcs, nil
+}
+
+// list returns the parsed blocks of the given ListBase, stored in the block
+// from, following the Next pointers of the elements.
+func (t *collections) list(from *block.Block, listBase reflect.Value) ([]*block.Block, error) {
+	if !listBase.IsValid() {
+		return nil, nil
+	}
+	var blks []*block.Block
+	seen := make(map[uint64]bool)
+	for addr := generic.PointerAddr(listBase.FieldByName("First")); addr != 0 && !seen[addr]; {
+		seen[addr] = true
+		blk, err := t.block(from, addr)
+		if err != nil {
+			return nil, err
+		}
+		blks = append(blks, blk)
+		from = blk
+		addr = generic.FieldAddr(blk.Body, "Next")
+	}
+	return blks, nil
+}
+
+// block returns the parsed block at the given address, referred to from the
+// block from.
+func (t *collections) block(from *block.Block, addr uint64) (*block.Block, error) {
+	loc, ok := t.r.Resolve(from, addr)
+	if !ok || loc.Offset != 0 {
+		return nil, fmt.Errorf("scene: unable to locate block at %#x", addr)
+	}
+	blk := loc.Block
+	if err := blk.ParseBody(t.dna); err != nil {
+		return nil, fmt.Errorf("scene: parsing %q block at %#x: %v", blk.Hdr.Code, addr, err)
+	}
+	return blk, nil
+}
+
+// trimCode trims the two-letter code prefix of an ID name.
+func trimCode(name string) string {
+	if len(name) < 2 {
+		return name
+	}
+	return name[2:]
+}
+
+// intField returns the value of the named integer field of body, or zero if no
+// such field exists.
+func intField(body any, name string) int {
+	v := generic.Field(body, name)
+	if !v.IsValid() {
+		return 0
+	}
+	if v.CanInt() {
+		return int(v.Int())
+	}
+	if v.CanUint() {
+		return int(v.Uint())
+	}
+	return 0
+}
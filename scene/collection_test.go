@@ -0,0 +1,118 @@
+package scene_test
+
+import (
+	"testing"
+
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/scene"
+)
+
+func TestScenes(t *testing.T) {
+	b, dna := decodeGolden(t, "../golden/v400_uncompressed.blend")
+	scenes, err := scene.Scenes(b, dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scenes) != 1 || scenes[0].Name != "Scene" {
+		t.Fatalf("expected scene %q, got %d scenes", "Scene", len(scenes))
+	}
+	sc := scenes[0]
+	if sc.Master == nil || sc.Master.Flag&scene.CollectionIsMaster == 0 {
+		t.Fatalf("invalid master collection %+v", sc.Master)
+	}
+	children := make(map[string]*scene.Collection)
+	for _, c := range sc.Master.Children {
+		children[c.Name] = c
+	}
+	lights, ok := children["Lights"]
+	if !ok {
+		t.Fatal("unable to locate collection \"Lights\"")
+	}
+	if lights.Flag&scene.CollectionHideRender == 0 {
+		t.Errorf("collection %q not hidden in renders (flag %#x)", lights.Name, lights.Flag)
+	}
+	if len(lights.Objects) != 5 {
+		t.Errorf("expected 5 objects in collection %q, got %d", lights.Name, len(lights.Objects))
+	}
+
+	if len(sc.ViewLayers) != 1 {
+		t.Fatalf("expected 1 view layer, got %d", len(sc.ViewLayers))
+	}
+	vl := sc.ViewLayers[0]
+	if !vl.Renders() {
+		t.Errorf("view layer %q not rendered", vl.Name)
+	}
+	n := 0
+	vl.Walk(func(lc *scene.LayerCollection, enabled bool) bool {
+		n++
+		if !enabled {
+			t.Errorf("layer collection of %q disabled", lc.Collection.Name)
+		}
+		return true
+	})
+	if n != 3 {
+		t.Errorf("expected 3 layer collections, got %d", n)
+	}
+	// Objects of the hidden "Lights" collection are not rendered.
+	want := map[string]bool{"Sphere": true, "Lighting Setup Generator": true, "Camera": true}
+	objs := vl.RenderObjects()
+	if len(objs) != len(want) {
+		t.Errorf("expected %d rendered objects, got %d", len(want), len(objs))
+	}
+	for _, obj := range objs {
+		if !want[obj.Name] {
+			t.Errorf("unexpected rendered object %q", obj.Name)
+		}
+	}
+}
+
+func TestScenesShadowed(t *testing.T) {
+	b, dna := decodeGolden(t, "../golden/v400_uncompressed.blend")
+	// DATA block addresses are only unique per owner; shadow the list elements
+	// of the collections by DATA blocks of another owner.
+	var shadows []*block.Block
+	for _, blk := range b.Blocks {
+		if blk.Hdr.Code == block.CodeDATA && blk.Hdr.SDNAIndex != 0 {
+			switch dna.Structs[blk.Hdr.SDNAIndex].Type {
+			case "CollectionObject", "CollectionChild", "LayerCollection":
+				shadow := &block.Block{Hdr: blk.Hdr, Body: make([]byte, blk.Hdr.Size)}
+				shadow.Hdr.SDNAIndex = 0
+				shadows = append(shadows, shadow)
+			}
+		}
+	}
+	if len(shadows) == 0 {
+		t.Fatal("unable to locate collection list elements of golden file")
+	}
+	var last *block.Block
+	for _, blk := range b.Blocks {
+		if blk.Hdr.Code != block.CodeDATA && blk.Hdr.Code != block.CodeDNA1 {
+			last = blk
+		}
+	}
+	b.Blocks = insertAfter(b.Blocks, last, shadows...)
+	for _, shadow := range shadows {
+		b.OldAddr[shadow.Hdr.OldAddr] = shadow
+	}
+
+	scenes, err := scene.Scenes(b, dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scenes) != 1 || len(scenes[0].ViewLayers) != 1 {
+		t.Fatalf("expected 1 scene with 1 view layer, got %d scenes", len(scenes))
+	}
+	if objs := scenes[0].ViewLayers[0].RenderObjects(); len(objs) != 3 {
+		t.Errorf("expected 3 rendered objects, got %d", len(objs))
+	}
+}
+
+// insertAfter inserts blks into the given blocks after the block after.
+func insertAfter(blocks []*block.Block, after *block.Block, blks ...*block.Block) []*block.Block {
+	for i, blk := range blocks {
+		if blk == after {
+			return append(blocks[:i+1], append(blks, blocks[i+1:]...)...)
+		}
+	}
+	return append(blocks, blks...)
+}
@@ -0,0 +1,243 @@
+// Package scene implements scene graph traversal of Blender files.
+package scene
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+)
+
+// Parent types of Object.Partype (masked by parTypeMask).
+const (
+	ParObject   = 0
+	ParSkel     = 4
+	ParVert1    = 5
+	ParVert3    = 6
+	ParBone     = 7
+	parTypeMask = 0xF
+)
+
+// A Graph is the object hierarchy of a blend file.
+type Graph struct {
+	// Roots contains the objects without a parent, in block order.
+	Roots []*Node
+	// Nodes maps the memory address of an object (when it was written to
+	// disk) to its node.
+	Nodes map[uint64]*Node
+}
+
+// A Node is an object of the scene graph.
+type Node struct {
+	// Object block.
+	Block *block.Block
+	// Object name, without the "OB" code prefix.
+	Name string
+	// Parent object; or nil if root.
+	Parent *Node
+	// Child objects, in block order.
+	Children []*Node
+	// Parent type (Object.Partype masked to the type bits).
+	ParType int
+
+	// Local transformation computed from the location, rotation and scale of
+	// the object, including delta transforms.
+	Local Matrix
+	// Parent inverse matrix (Object.Parentinv).
+	ParentInv Matrix
+	// World transformation computed from Local, ParentInv and the world
+	// transformation of the parent.
+	World Matrix
+	// World transformation stored in the file (Object.Obmat).
+	Stored Matrix
+}
+
+// NewGraph builds the object hierarchy of the OB blocks in b and computes the
+// world transformation of every object.
+func NewGraph(b *blend.Blend, dna *block.DNA) (*Graph, error) {
+	g := &Graph{Nodes: make(map[uint64]*Node)}
+	var nodes []*Node
+	parents := make(map[*Node]uint64)
+	for _, blk := range b.Blocks {
+		if blk.Hdr.Code != block.CodeOB {
+			continue
+		}
+		if err := blk.ParseBody(dna); err != nil {
+			return nil, fmt.Errorf("scene.NewGraph: parsing object at %#x: %v", blk.Hdr.OldAddr, err)
+		}
+		n, err := newNode(blk)
+		if err != nil {
+			return nil, err
+		}
+		g.Nodes[blk.Hdr.OldAddr] = n
+		nodes = append(nodes, n)
+		parents[n] = generic.FieldAddr(blk.Body, "Parent")
+	}
+
+	// Link parents and children.
+	for _, n := range nodes {
+		parent, ok := g.Nodes[parents[n]]
+		if !ok {
+			g.Roots = append(g.Roots, n)
+			continue
+		}
+		n.Parent = parent
+		parent.Children = append(parent.Children, n)
+	}
+
+	for _, n := range g.Roots {
+		n.update()
+	}
+	return g, nil
+}
+
+// newNode returns a scene graph node for the given object block, with the
+// local transformation computed.
+func newNode(blk *block.Block) (*Node, error) {
+	body := blk.Body
+	n := &Node{Block: blk}
+
+	name := generic.IDName(body)
+	if len(name) == 0 {
+		return nil, fmt.Errorf("scene.newNode: object at %#x has unexpected type %T", blk.Hdr.OldAddr, body)
+	}
+	n.Name = trimCode(name)
+
+	var parType int16
+	field(body, "Partype", &parType)
+	n.ParType = int(parType) & parTypeMask
+
+	var loc, dloc, size, dscale, rot, drot, axis, daxis [3]float32
+	var quat, dquat [4]float32
+	var angle, dangle float32
+	var rotMode int16
+	field(body, "Loc", &loc)
+	field(body, "Dloc", &dloc)
+	field(body, "Size", &size)
+	field(body, "Dscale", &dscale)
+	field(body, "Rot", &rot)
+	field(body, "Drot", &drot)
+	field(body, "Quat", &quat)
+	field(body, "Dquat", &dquat)
+	field(body, "RotAxis", &axis)
+	field(body, "DrotAxis", &daxis)
+	field(body, "RotAngle", &angle)
+	field(body, "DrotAngle", &dangle)
+	field(body, "Rotmode", &rotMode)
+
+	// Rotation; see BKE_object_rot_to_mat3.
+	var rmat, dmat mat3
+	switch mode := int(rotMode); {
+	case mode > 0:
+		rmat = eulerToMat3(rot, mode)
+		dmat = eulerToMat3(drot, mode)
+	case mode == RotModeAxisAngle:
+		rmat = axisAngleToMat3(axis, angle)
+		dmat = axisAngleToMat3(daxis, dangle)
+	default:
+		rmat = quatToMat3(quat)
+		dmat = quatToMat3(dquat)
+	}
+	m := dmat.mul(rmat)
+
+	// Scale; see BKE_object_to_mat4.
+	for col := 0; col < 3; col++ {
+		s := float64(size[col]) * float64(dscale[col])
+		for row := 0; row < 3; row++ {
+			n.Local[col][row] = float32(m[col][row] * s)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		n.Local[3][i] = loc[i] + dloc[i]
+	}
+	n.Local[3][3] = 1
+
+	field(body, "Parentinv", (*[4][4]float32)(&n.ParentInv))
+	field(body, "Obmat", (*[4][4]float32)(&n.Stored))
+	return n, nil
+}
+
+// update computes the world transformation of n and its descendants.
+func (n *Node) update() {
+	if n.Parent == nil {
+		n.World = n.Local
+	} else {
+		n.World = n.Parent.World.Mul(n.ParentInv).Mul(n.Local)
+	}
+	for _, child := range n.Children {
+		child.update()
+	}
+}
+
+// Walk calls fn for every node of the graph in depth-first order, parents
+// before children. Walk stops if fn returns false.
+func (g *Graph) Walk(fn func(n *Node) bool) {
+	var walk func(n *Node) bool
+	walk = func(n *Node) bool {
+		if !fn(n) {
+			return false
+		}
+		for _, child := range n.Children {
+			if !walk(child) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, n := range g.Roots {
+		if !walk(n) {
+			return
+		}
+	}
+}
+
+// A Mismatch describes an object whose computed world transformation differs
+// from the one stored in the file.
+type Mismatch struct {
+	Node *Node
+	// Supported is false if the parent type (e.g. bone or vertex parent) is
+	// not taken into account by the computed world transformation.
+	Supported bool
+}
+
+func (m Mismatch) String() string {
+	if !m.Supported {
+		return fmt.Sprintf("%s: unsupported parent type %d", m.Node.Name, m.Node.ParType)
+	}
+	return fmt.Sprintf("%s: computed world matrix %v differs from stored %v", m.Node.Name, m.Node.World, m.Node.Stored)
+}
+
+// Validate compares the computed world transformation of every object with the
+// one stored in the file, and returns the objects which differ by more than
+// eps. Constraints and drivers are not evaluated, so objects affected by them
+// are reported as well.
+func (g *Graph) Validate(eps float32) []Mismatch {
+	var mismatches []Mismatch
+	g.Walk(func(n *Node) bool {
+		if n.World.Equal(n.Stored, eps) {
+			return true
+		}
+		supported := true
+		if n.Parent != nil {
+			switch n.ParType {
+			case ParObject, ParSkel:
+			default:
+				supported = false
+			}
+		}
+		mismatches = append(mismatches, Mismatch{Node: n, Supported: supported})
+		return true
+	})
+	return mismatches
+}
+
+// field stores the named field of body in dst, if present and of the same type.
+func field[T any](body any, name string, dst *T) {
+	v := generic.Field(body, name)
+	if !v.IsValid() || v.Type() != reflect.TypeOf(*dst) {
+		return
+	}
+	*dst = v.Interface().(T)
+}
@@ -0,0 +1,118 @@
+package scene_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/file"
+	"github.com/mewspring/blend/scene"
+)
+
+// decodeGolden decodes the given golden file, and returns it with its DNA.
+func decodeGolden(t *testing.T, path string) (*blend.Blend, *block.DNA) {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Skip(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	d, err := file.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := blend.Decode(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dna, err := b.GetDNA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, dna
+}
+
+// reencode encodes b, and returns the decoded result with its DNA.
+func reencode(t *testing.T, b *blend.Blend) (*blend.Blend, *block.DNA) {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := blend.Encode(buf, b); err != nil {
+		t.Fatal(err)
+	}
+	d, err := file.NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := blend.Decode(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dna, err := got.GetDNA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got, dna
+}
+
+func TestGraph(t *testing.T) {
+	b, dna := decodeGolden(t, "../golden/v400_uncompressed.blend")
+	g, err := scene.NewGraph(b, dna)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	g.Walk(func(n *scene.Node) bool {
+		names = append(names, n.Name)
+		return true
+	})
+	want := []string{"Area", "Area.001", "Area.002", "Area.003", "Area.004", "Camera", "Lighting Setup Generator", "Sphere"}
+	if len(names) != len(want) {
+		t.Fatalf("objects mismatch; expected %q, got %q", want, names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("objects mismatch; expected %q, got %q", want, names)
+		}
+	}
+	if len(g.Roots) != len(want) {
+		t.Errorf("expected %d root objects, got %d", len(want), len(g.Roots))
+	}
+	// The world transformations of unparented objects are stored in the file.
+	if mismatches := g.Validate(1e-4); len(mismatches) > 0 {
+		t.Errorf("world transformations differ from the stored ones: %v", mismatches)
+	}
+
+	// The hierarchy survives re-encoding.
+	b2, dna2 := reencode(t, b)
+	g2, err := scene.NewGraph(b2, dna2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for addr, n := range g.Nodes {
+		n2, ok := g2.Nodes[addr]
+		if !ok {
+			t.Errorf("object %q at %#x missing after re-encoding", n.Name, addr)
+			continue
+		}
+		if n2.Name != n.Name || !n2.World.Equal(n.World, 0) {
+			t.Errorf("object %q at %#x mismatch after re-encoding; got %q with world transformation %v", n.Name, addr, n2.Name, n2.World)
+		}
+	}
+}
+
+func TestMatrixMul(t *testing.T) {
+	m := scene.Matrix{
+		{1, 0, 0, 0},
+		{0, 2, 0, 0},
+		{0, 0, 3, 0},
+		{4, 5, 6, 1},
+	}
+	if got := m.Mul(scene.Identity()); !got.Equal(m, 0) {
+		t.Errorf("m * identity mismatch; expected %v, got %v", m, got)
+	}
+	if got := scene.Identity().Mul(m); !got.Equal(m, 0) {
+		t.Errorf("identity * m mismatch; expected %v, got %v", m, got)
+	}
+}
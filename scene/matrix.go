@@ -0,0 +1,162 @@
+package scene
+
+import (
+	"math"
+)
+
+// Matrix is a 4x4 transformation matrix stored in the same column-major layout
+// as Blender's float[4][4], i.e. m[3] holds the translation.
+type Matrix [4][4]float32
+
+// Identity returns the identity matrix.
+func Identity() Matrix {
+	return Matrix{
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// Mul returns the matrix product m*n.
+func (m Matrix) Mul(n Matrix) Matrix {
+	var r Matrix
+	for col := 0; col < 4; col++ {
+		for row := 0; row < 4; row++ {
+			var sum float64
+			for k := 0; k < 4; k++ {
+				sum += float64(m[k][row]) * float64(n[col][k])
+			}
+			r[col][row] = float32(sum)
+		}
+	}
+	return r
+}
+
+// Equal reports whether every element of m and n differs by at most eps.
+func (m Matrix) Equal(n Matrix, eps float32) bool {
+	for col := range m {
+		for row := range m[col] {
+			if d := m[col][row] - n[col][row]; d > eps || d < -eps {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// mat3 is a 3x3 rotation or scale matrix in column-major layout.
+type mat3 [3][3]float64
+
+func (m mat3) mul(n mat3) mat3 {
+	var r mat3
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			for k := 0; k < 3; k++ {
+				r[col][row] += m[k][row] * n[col][k]
+			}
+		}
+	}
+	return r
+}
+
+// Rotation modes of Object.Rotmode.
+const (
+	RotModeQuat      = 0
+	RotModeXYZ       = 1
+	RotModeXZY       = 2
+	RotModeYXZ       = 3
+	RotModeYZX       = 4
+	RotModeZXY       = 5
+	RotModeZYX       = 6
+	RotModeAxisAngle = -1
+)
+
+// rotOrders maps Euler rotation modes to axis order and parity, as defined by
+// rotOrders in Blender's math_rotation.c.
+var rotOrders = map[int]struct {
+	axis   [3]int
+	parity bool
+}{
+	RotModeXYZ: {[3]int{0, 1, 2}, false},
+	RotModeXZY: {[3]int{0, 2, 1}, true},
+	RotModeYXZ: {[3]int{1, 0, 2}, true},
+	RotModeYZX: {[3]int{1, 2, 0}, false},
+	RotModeZXY: {[3]int{2, 0, 1}, false},
+	RotModeZYX: {[3]int{2, 1, 0}, true},
+}
+
+// eulerToMat3 converts the Euler rotation e in the given rotation mode to a
+// rotation matrix.
+func eulerToMat3(e [3]float32, mode int) mat3 {
+	order, ok := rotOrders[mode]
+	if !ok {
+		order = rotOrders[RotModeXYZ]
+	}
+	i, j, k := order.axis[0], order.axis[1], order.axis[2]
+	ti, tj, th := float64(e[i]), float64(e[j]), float64(e[k])
+	if order.parity {
+		ti, tj, th = -ti, -tj, -th
+	}
+	ci, cj, ch := math.Cos(ti), math.Cos(tj), math.Cos(th)
+	si, sj, sh := math.Sin(ti), math.Sin(tj), math.Sin(th)
+	cc, cs, sc, ss := ci*ch, ci*sh, si*ch, si*sh
+
+	var m mat3
+	m[i][i] = cj * ch
+	m[j][i] = sj*sc - cs
+	m[k][i] = sj*cc + ss
+	m[i][j] = cj * sh
+	m[j][j] = sj*ss + cc
+	m[k][j] = sj*cs - sc
+	m[i][k] = -sj
+	m[j][k] = cj * si
+	m[k][k] = cj * ci
+	return m
+}
+
+// quatToMat3 converts the quaternion q (w, x, y, z) to a rotation matrix. The
+// quaternion is normalized first.
+func quatToMat3(q [4]float32) mat3 {
+	var n float64
+	for _, x := range q {
+		n += float64(x) * float64(x)
+	}
+	n = math.Sqrt(n)
+	if n == 0 {
+		return mat3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	}
+	q0 := math.Sqrt2 * float64(q[0]) / n
+	q1 := math.Sqrt2 * float64(q[1]) / n
+	q2 := math.Sqrt2 * float64(q[2]) / n
+	q3 := math.Sqrt2 * float64(q[3]) / n
+
+	qda, qdb, qdc := q0*q1, q0*q2, q0*q3
+	qaa, qab, qac := q1*q1, q1*q2, q1*q3
+	qbb, qbc, qcc := q2*q2, q2*q3, q3*q3
+
+	return mat3{
+		{1 - qbb - qcc, qdc + qab, -qdb + qac},
+		{-qdc + qab, 1 - qaa - qcc, qda + qbc},
+		{qdb + qac, -qda + qbc, 1 - qaa - qbb},
+	}
+}
+
+// axisAngleToMat3 converts a rotation of angle radians around axis to a
+// rotation matrix.
+func axisAngleToMat3(axis [3]float32, angle float32) mat3 {
+	x, y, z := float64(axis[0]), float64(axis[1]), float64(axis[2])
+	n := math.Sqrt(x*x + y*y + z*z)
+	if n == 0 {
+		return mat3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	}
+	x, y, z = x/n, y/n, z/n
+
+	co, si := math.Cos(float64(angle)), math.Sin(float64(angle))
+	ico := 1 - co
+	return mat3{
+		{x*x*ico + co, x*y*ico + z*si, x*z*ico - y*si},
+		{x*y*ico - z*si, y*y*ico + co, y*z*ico + x*si},
+		{x*z*ico + y*si, y*z*ico - x*si, z*z*ico + co},
+	}
+}
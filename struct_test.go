@@ -0,0 +1,199 @@
+package blend_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/block/generic"
+	v401 "github.com/mewspring/blend/block/v401"
+	"github.com/mewspring/blend/file"
+)
+
+// decodeGolden decodes the given golden file, and returns it with its DNA.
+func decodeGolden(t testing.TB, path string) (*blend.Blend, *block.DNA) {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Skip(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	d, err := file.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := blend.Decode(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dna, err := b.GetDNA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, dna
+}
+
+// goType returns the Go structure type of the given SDNA type, as decoded by
+// parse; or nil if the type is unknown to parse.
+func goType(parse func(io.Reader, binary.ByteOrder, int, string, uint32) (any, error), typ string) reflect.Type {
+	body, err := parse(bytes.NewReader(nil), binary.LittleEndian, 8, typ, 0)
+	if err != nil || body == nil {
+		return nil
+	}
+	return reflect.TypeOf(body).Elem().Elem()
+}
+
+// TestStructSizes checks that the generated Go structure definitions of the
+// golden files are encoded in the sizes given by their DNA.
+func TestStructSizes(t *testing.T) {
+	golden := []string{
+		"golden/v305_uncompressed.blend",
+		"golden/v400_uncompressed.blend",
+	}
+	for _, path := range golden {
+		t.Run(path, func(t *testing.T) {
+			b, dna := decodeGolden(t, path)
+			parse := block.Versions[b.Hdr.Ver].ParseStructure
+			for _, s := range dna.Structs {
+				typ := goType(parse, s.Type)
+				if typ == nil {
+					t.Errorf("no Go structure definition of %q", s.Type)
+					continue
+				}
+				want := dna.TypeSize(s.Type)
+				if got := generic.EncodedSize(reflect.New(typ).Interface(), b.Hdr.PtrSize); got != want {
+					t.Errorf("%v is encoded in %d bytes; DNA size of %q is %d bytes", typ, got, s.Type, want)
+				}
+			}
+		})
+	}
+}
+
+// TestFuncPointers checks that only the function pointers of the DNA are
+// generated as FuncPointer, and not the pointers to arrays stored in the same
+// notation (e.g. "float (*disps)[3]" as "(*disps)()").
+func TestFuncPointers(t *testing.T) {
+	golden := []string{
+		"golden/v305_uncompressed.blend",
+		"golden/v400_uncompressed.blend",
+	}
+	funcType := reflect.TypeOf(generic.FuncPointer{})
+	for _, path := range golden {
+		t.Run(path, func(t *testing.T) {
+			b, dna := decodeGolden(t, path)
+			parse := block.Versions[b.Hdr.Ver].ParseStructure
+			n := 0
+			for _, s := range dna.Structs {
+				typ := goType(parse, s.Type)
+				if typ == nil || typ.NumField() != len(s.Fields) {
+					continue
+				}
+				for i, f := range s.Fields {
+					if f.IsFuncPointer() != (typ.Field(i).Type == funcType) {
+						t.Errorf("%s.%s of type %q generated as %v", s.Type, f.Name, f.Type, typ.Field(i).Type)
+					}
+					if f.IsFuncPointer() {
+						n++
+					}
+				}
+			}
+			if n == 0 {
+				t.Error("no function pointers in DNA")
+			}
+			disps := reflect.New(goType(parse, "MDisps")).Elem().FieldByName("Disps")
+			if !generic.IsPointer(disps) {
+				t.Errorf("MDisps.Disps generated as %v", disps.Type())
+			}
+			if generic.IsPointer(reflect.ValueOf(generic.FuncPointer{})) {
+				t.Error("function pointer reported as block pointer")
+			}
+		})
+	}
+}
+
+// TestStructSizesV401 checks the Go structure definitions of Blender 4.1. As no
+// 4.1 golden file is at hand, the structures with the same layout as in
+// Blender 4.0 are checked against the DNA of the 4.0 golden file; and no
+// structure may contain empty structures, such as those generated for unknown
+// C types.
+func TestStructSizesV401(t *testing.T) {
+	b, dna := decodeGolden(t, "golden/v400_uncompressed.blend")
+	parse := block.Versions[b.Hdr.Ver].ParseStructure
+	checked := make(map[reflect.Type]bool)
+	n := 0
+	for _, s := range dna.Structs {
+		typ := goType(v401.ParseStructure, s.Type)
+		if typ == nil {
+			// Removed in Blender 4.1.
+			continue
+		}
+		checkNonEmpty(t, typ, checked)
+		if !sameLayout(goType(parse, s.Type), typ) {
+			continue
+		}
+		n++
+		want := dna.TypeSize(s.Type)
+		if got := generic.EncodedSize(reflect.New(typ).Interface(), b.Hdr.PtrSize); got != want {
+			t.Errorf("%v is encoded in %d bytes; DNA size of %q in Blender 4.0 is %d bytes", typ, got, s.Type, want)
+		}
+	}
+	if n == 0 {
+		t.Fatal("no structures of Blender 4.0 and 4.1 share their layout")
+	}
+}
+
+// checkNonEmpty reports an error for every empty structure type contained in
+// typ.
+func checkNonEmpty(t *testing.T, typ reflect.Type, checked map[reflect.Type]bool) {
+	if checked[typ] {
+		return
+	}
+	checked[typ] = true
+	switch typ.Kind() {
+	case reflect.Array:
+		checkNonEmpty(t, typ.Elem(), checked)
+	case reflect.Struct:
+		if typ.NumField() == 0 {
+			t.Errorf("empty structure %v", typ)
+		}
+		for i := 0; i < typ.NumField(); i++ {
+			checkNonEmpty(t, typ.Field(i).Type, checked)
+		}
+	}
+}
+
+// sameLayout reports whether the Go structure types a and b have the same
+// fields, and thus the same encoded size.
+func sameLayout(a, b reflect.Type) bool {
+	if a == nil || b == nil || a.Kind() != b.Kind() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Array:
+		return a.Len() == b.Len() && sameLayout(a.Elem(), b.Elem())
+	case reflect.Struct:
+		if a.NumField() != b.NumField() {
+			return false
+		}
+		for i := 0; i < a.NumField(); i++ {
+			fa, fb := a.Field(i), b.Field(i)
+			if fa.Name != fb.Name || fa.Tag != fb.Tag {
+				return false
+			}
+			if fa.Tag.Get("bin") == "ptrSize" {
+				// Pointer-sized field.
+				continue
+			}
+			if !sameLayout(fa.Type, fb.Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package blend
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/file"
+)
+
+// Walk streams the file blocks of the blend file read by d, and calls fn for
+// each block selected by filter, together with the DNA of the blend file. The
+// walk stops early if fn returns block.SkipAll.
+//
+// Contrary to Decode, the blocks are not retained; Walk may thereby be used to
+// scan files of any size with constant memory, as long as fn does not retain
+// the blocks either. The bodies of selected blocks are read from d when parsed
+// by fn, and may only be parsed until Walk returns.
+//
+// The DNA block is stored near the end of blend files; Walk therefore first
+// skims through the block headers to locate and parse the DNA block, before
+// walking the blocks.
+func Walk(d *file.Reader, filter block.Filter, fn func(dna *block.DNA, blk *block.Block) error) error {
+	hdr, err := ReadHeader(d)
+	if err != nil {
+		return fmt.Errorf("blend.Walk: reading header: %v", err)
+	}
+	start, err := d.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("blend.Walk: %v", err)
+	}
+
+	// Locate DNA block.
+	r := block.NewReader(hdr.Order, hdr.PtrSize, hdr.Ver)
+	var dna *block.DNA
+	dnaFilter := block.Filter{Codes: []block.Code{block.CodeDNA1}}
+	err = r.Walk(d, nil, dnaFilter, func(blk *block.Block) error {
+		if err := blk.ParseBody(nil); err != nil {
+			return err
+		}
+		dna = blk.Body.(*block.DNA)
+		return block.SkipAll
+	})
+	if err != nil {
+		return fmt.Errorf("blend.Walk: %v", err)
+	}
+	if dna == nil {
+		return errors.New("blend.Walk: unable to locate DNA block")
+	}
+
+	// Walk file blocks.
+	if _, err := d.Seek(start, io.SeekStart); err != nil {
+		return fmt.Errorf("blend.Walk: %v", err)
+	}
+	return r.Walk(d, dna, filter, func(blk *block.Block) error {
+		return fn(dna, blk)
+	})
+}
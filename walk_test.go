@@ -0,0 +1,136 @@
+package blend_test
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/mewspring/blend"
+	"github.com/mewspring/blend/block"
+	"github.com/mewspring/blend/file"
+)
+
+// walkGolden walks the blocks of the given golden file selected by filter.
+func walkGolden(t *testing.T, path string, filter block.Filter, fn func(dna *block.DNA, blk *block.Block) error) {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer f.Close()
+	d, err := file.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blend.Walk(d, filter, fn); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	const path = "golden/v400_uncompressed.blend"
+	b, dna := decodeGolden(t, path)
+	var want []block.Header
+	for _, blk := range b.Blocks {
+		if blk.Hdr.Code != block.CodeENDB {
+			want = append(want, blk.Hdr)
+		}
+	}
+	var got []block.Header
+	walkGolden(t, path, block.Filter{}, func(walkDNA *block.DNA, blk *block.Block) error {
+		got = append(got, blk.Hdr)
+		if blk.Hdr.Code != block.CodeOB {
+			return nil
+		}
+		// Bodies parse as when decoded.
+		if err := blk.ParseBody(walkDNA); err != nil {
+			return err
+		}
+		dec := b.OldAddr[blk.Hdr.OldAddr]
+		if err := dec.ParseBody(dna); err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(blk.Body, dec.Body) {
+			t.Errorf("body of %q block at %#x mismatch", blk.Hdr.Code, blk.Hdr.OldAddr)
+		}
+		return nil
+	})
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("walked %d blocks differ from %d decoded blocks", len(got), len(want))
+	}
+}
+
+func TestWalkFilter(t *testing.T) {
+	const path = "golden/v400_uncompressed.blend"
+	b, dna := decodeGolden(t, path)
+	count := func(match func(blk *block.Block) bool) int {
+		n := 0
+		for _, blk := range b.Blocks {
+			if match(blk) {
+				n++
+			}
+		}
+		return n
+	}
+	golden := []struct {
+		filter block.Filter
+		want   int
+	}{
+		{
+			filter: block.Filter{Codes: []block.Code{block.CodeOB}},
+			want:   count(func(blk *block.Block) bool { return blk.Hdr.Code == block.CodeOB }),
+		},
+		{
+			filter: block.Filter{Types: []string{"Mesh"}},
+			want: count(func(blk *block.Block) bool {
+				return blk.Hdr.SDNAIndex != 0 && dna.Structs[blk.Hdr.SDNAIndex].Type == "Mesh"
+			}),
+		},
+		{
+			filter: block.Filter{Codes: []block.Code{block.CodeOB}, Types: []string{"Mesh"}},
+			want: count(func(blk *block.Block) bool {
+				return blk.Hdr.Code == block.CodeOB || blk.Hdr.SDNAIndex != 0 && dna.Structs[blk.Hdr.SDNAIndex].Type == "Mesh"
+			}),
+		},
+	}
+	for _, g := range golden {
+		if g.want == 0 {
+			t.Fatalf("no blocks of golden file selected by %+v", g.filter)
+		}
+		n := 0
+		walkGolden(t, path, g.filter, func(dna *block.DNA, blk *block.Block) error {
+			n++
+			return nil
+		})
+		if n != g.want {
+			t.Errorf("%+v: expected %d blocks, got %d", g.filter, g.want, n)
+		}
+	}
+
+	// The walk stops early on SkipAll.
+	n := 0
+	walkGolden(t, path, block.Filter{}, func(dna *block.DNA, blk *block.Block) error {
+		n++
+		return block.SkipAll
+	})
+	if n != 1 {
+		t.Errorf("expected 1 block before SkipAll, got %d", n)
+	}
+}
+
+func TestWalkAlloc(t *testing.T) {
+	// Walked blocks are not retained; their bodies do not count against the
+	// total allocation limit.
+	const path = "golden/v400_uncompressed.blend"
+	old := block.DefaultLimits
+	defer func() { block.DefaultLimits = old }()
+	block.DefaultLimits.MaxAlloc = 1 << 20
+	var total int64
+	walkGolden(t, path, block.Filter{}, func(dna *block.DNA, blk *block.Block) error {
+		total += blk.Hdr.Size
+		return blk.ParseBody(dna)
+	})
+	if total <= block.DefaultLimits.MaxAlloc {
+		t.Fatalf("walked %d bytes of block bodies, within limit of %d bytes", total, block.DefaultLimits.MaxAlloc)
+	}
+}